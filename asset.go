@@ -0,0 +1,196 @@
+package kook
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AssetMaxSize is the default size limit of AssetCreateFromReader. Kook does not document the limit,
+// set it with AssetWithMaxSize if a different one applies.
+const AssetMaxSize int64 = 50 << 20
+
+// These are the errors of checks before uploading assets.
+var (
+	ErrAssetTooLarge   = errors.New("asset too large")
+	ErrAssetSize       = errors.New("asset size mismatch")
+	ErrAssetEmpty      = errors.New("asset is empty")
+	ErrAssetTypeDenied = errors.New("asset type not allowed")
+)
+
+// AssetProgress is the progress of uploading an asset.
+type AssetProgress struct {
+	// Sent is the number of bytes of the file sent.
+	Sent int64
+	// Total is the size of the file, or -1 if unknown.
+	Total int64
+}
+
+// AssetOption is the type for optional arguments of AssetCreateFromReader.
+type AssetOption func(*assetConfig)
+
+type assetConfig struct {
+	maxSize  int64
+	types    []string
+	progress func(AssetProgress)
+}
+
+// AssetWithMaxSize sets the size limit checked before and during uploading, 0 disables the check.
+func AssetWithMaxSize(size int64) AssetOption {
+	return func(c *assetConfig) {
+		c.maxSize = size
+	}
+}
+
+// AssetWithContentTypes only allows the content types, which are sniffed by http.DetectContentType.
+// A type ending with "/" matches all types under it, e.g. "image/".
+func AssetWithContentTypes(types ...string) AssetOption {
+	return func(c *assetConfig) {
+		c.types = types
+	}
+}
+
+// AssetWithProgress sets the function called while the file is sent.
+func AssetWithProgress(f func(AssetProgress)) AssetOption {
+	return func(c *assetConfig) {
+		c.progress = f
+	}
+}
+
+func (c *assetConfig) allowed(contentType string) bool {
+	if len(c.types) == 0 {
+		return true
+	}
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	for _, t := range c.types {
+		if t == mediaType || strings.HasSuffix(t, "/") && strings.HasPrefix(mediaType, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// assetStream is the multipart body streamed to kook, which could not be sent again on retry.
+type assetStream struct {
+	ctx         context.Context
+	Body        io.Reader
+	ContentType string
+}
+
+// assetReader counts the bytes read from the file, and fails if the size is exceeded or not matched.
+type assetReader struct {
+	r        io.Reader
+	size     int64
+	maxSize  int64
+	sent     int64
+	progress func(AssetProgress)
+}
+
+func (a *assetReader) Read(p []byte) (n int, err error) {
+	n, err = a.r.Read(p)
+	a.sent += int64(n)
+	if a.maxSize > 0 && a.sent > a.maxSize {
+		return n, ErrAssetTooLarge
+	}
+	if a.size >= 0 && (a.sent > a.size || err == io.EOF && a.sent != a.size) {
+		return n, fmt.Errorf("%w: expected %d bytes, read %d", ErrAssetSize, a.size, a.sent)
+	}
+	if err == io.EOF && a.sent == 0 {
+		return n, ErrAssetEmpty
+	}
+	if n > 0 && a.progress != nil {
+		a.progress(AssetProgress{Sent: a.sent, Total: a.size})
+	}
+	return
+}
+
+// AssetCreateFromReader uploads the file read from r without holding it in memory.
+// size is the size of the file, or -1 if unknown. The content type is sniffed from the beginning of the file,
+// and the size and type are checked before uploading.
+//
+// FYI: https://developer.kookapp.cn/doc/http/asset#%E4%B8%8A%E4%BC%A0%E6%96%87%E4%BB%B6/%E5%9B%BE%E7%89%87
+func (s *Session) AssetCreateFromReader(ctx context.Context, name string, r io.Reader, size int64, options ...AssetOption) (url string, err error) {
+	c := assetConfig{maxSize: AssetMaxSize}
+	for _, item := range options {
+		item(&c)
+	}
+	if size == 0 {
+		return "", ErrAssetEmpty
+	}
+	if c.maxSize > 0 && size > c.maxSize {
+		return "", fmt.Errorf("%w: %d bytes exceeds %d", ErrAssetTooLarge, size, c.maxSize)
+	}
+	br := bufio.NewReaderSize(r, 512)
+	head, err := br.Peek(512)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if len(head) == 0 {
+		return "", ErrAssetEmpty
+	}
+	contentType := http.DetectContentType(head)
+	if !c.allowed(contentType) {
+		return "", fmt.Errorf("%w: %s", ErrAssetTypeDenied, contentType)
+	}
+	if err = ctx.Err(); err != nil {
+		return "", err
+	}
+
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	w := multipart.NewWriter(pw)
+	go func() {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(name)))
+		h.Set("Content-Type", contentType)
+		fw, err := w.CreatePart(h)
+		if err == nil {
+			_, err = io.Copy(fw, &assetReader{r: br, size: size, maxSize: c.maxSize, progress: c.progress})
+		}
+		if err == nil {
+			err = w.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	var response []byte
+	response, err = s.Request("POST", EndpointAssetCreate, &assetStream{ctx: ctx, Body: pr, ContentType: w.FormDataContentType()})
+	if err != nil {
+		return "", err
+	}
+	urlStruct := struct {
+		URL string `json:"url"`
+	}{}
+	err = json.Unmarshal(response, &urlStruct)
+	if err != nil {
+		return "", err
+	}
+	return urlStruct.URL, nil
+}
+
+// AssetCreateFromFile uploads the file at path, named after its base name. See AssetCreateFromReader.
+func (s *Session) AssetCreateFromFile(ctx context.Context, path string, options ...AssetOption) (url string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("%s is a directory", path)
+	}
+	return s.AssetCreateFromReader(ctx, filepath.Base(path), f, info.Size(), options...)
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
@@ -0,0 +1,74 @@
+package kook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSession_AssetCreateFromReader(t *testing.T) {
+	png := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 100000)...)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/asset/create" {
+			t.Error(r.URL.Path)
+		}
+		f, h, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		b, _ := ioutil.ReadAll(f)
+		if h.Filename != "a.png" || h.Header.Get("Content-Type") != "image/png" || !bytes.Equal(b, png) {
+			t.Error(h.Filename, h.Header, len(b))
+		}
+		w.Write([]byte(`{"code":0,"message":"","data":{"url":"https://img.kookapp.cn/a.png"}}`))
+	}))
+	defer ts.Close()
+	s := New("", mockLogger{}, SessionWithAPIBase(ts.URL))
+	ctx := context.Background()
+	var last AssetProgress
+	get, err := s.AssetCreateFromReader(ctx, "a.png", bytes.NewReader(png), int64(len(png)),
+		AssetWithContentTypes("image/"), AssetWithProgress(func(p AssetProgress) { last = p }))
+	if err != nil || get != "https://img.kookapp.cn/a.png" {
+		t.Error(get, err)
+	}
+	if last.Sent != int64(len(png)) || last.Total != int64(len(png)) {
+		t.Error(last)
+	}
+
+	path := filepath.Join(t.TempDir(), "a.png")
+	if err = os.WriteFile(path, png, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if get, err = s.AssetCreateFromFile(ctx, path); err != nil || get != "https://img.kookapp.cn/a.png" {
+		t.Error(get, err)
+	}
+
+	_, err = s.AssetCreateFromReader(ctx, "a.txt", strings.NewReader("hello"), 5, AssetWithContentTypes("image/", "video/mp4"))
+	if !errors.Is(err, ErrAssetTypeDenied) {
+		t.Error(err)
+	}
+	_, err = s.AssetCreateFromReader(ctx, "a.png", bytes.NewReader(png), int64(len(png)), AssetWithMaxSize(1000))
+	if !errors.Is(err, ErrAssetTooLarge) {
+		t.Error(err)
+	}
+	_, err = s.AssetCreateFromReader(ctx, "a.png", bytes.NewReader(png), -1, AssetWithMaxSize(1000))
+	if !errors.Is(err, ErrAssetTooLarge) {
+		t.Error(err)
+	}
+	_, err = s.AssetCreateFromReader(ctx, "a.png", bytes.NewReader(png), int64(len(png))+1)
+	if !errors.Is(err, ErrAssetSize) {
+		t.Error(err)
+	}
+	_, err = s.AssetCreateFromReader(ctx, "a.png", strings.NewReader(""), -1)
+	if !errors.Is(err, ErrAssetEmpty) {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,263 @@
+package kook
+
+import (
+	"context"
+	"regexp"
+	"time"
+)
+
+// MessageFilter selects messages of a channel for bulk operations. Zero fields match all messages.
+type MessageFilter struct {
+	// AuthorIDs matches messages sent by any of the users.
+	AuthorIDs []string
+	// Content matches messages whose content matches the regexp.
+	Content *regexp.Regexp
+	// After and Before match messages sent in the time range.
+	After  time.Time
+	Before time.Time
+	// AfterMessage matches messages sent after the message, e.g. to clean up everything after it.
+	AfterMessage string
+	// HasAttachment matches only messages with an attachment.
+	HasAttachment bool
+	// Limit stops after so many messages are matched.
+	Limit int
+}
+
+// Match reports whether the message is selected by the filter, except for AfterMessage and Limit.
+func (f *MessageFilter) Match(m *DetailedChannelMessage) bool {
+	if len(f.AuthorIDs) != 0 {
+		found := false
+		for _, id := range f.AuthorIDs {
+			if m.Author.ID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Content != nil && !f.Content.MatchString(m.Content) {
+		return false
+	}
+	if !f.After.IsZero() && m.CreateAt < MilliTimeStampOfTime(f.After) {
+		return false
+	}
+	if !f.Before.IsZero() && m.CreateAt > MilliTimeStampOfTime(f.Before) {
+		return false
+	}
+	if f.HasAttachment && (m.Attachments == nil || m.Attachments.URL == "") {
+		return false
+	}
+	return true
+}
+
+// history returns the options walking the messages possibly matched, from the newest to the oldest
+// unless AfterMessage is set.
+func (f *MessageFilter) history() []HistoryOption {
+	if f.AfterMessage != "" {
+		return []HistoryOption{
+			HistoryWithDirection(HistoryForward),
+			HistoryFromMessage(f.AfterMessage),
+			HistoryUntil(f.Before),
+		}
+	}
+	return []HistoryOption{
+		HistoryFromTime(f.Before),
+		HistoryUntil(f.After),
+	}
+}
+
+// BulkProgress is the progress reported during bulk operations.
+type BulkProgress struct {
+	// Scanned is the number of messages listed so far.
+	Scanned int
+	// Matched is the number of messages selected so far.
+	Matched int
+	// Succeeded and Failed count the operations done so far.
+	Succeeded int
+	Failed    int
+	// MsgID is the message just processed.
+	MsgID string
+}
+
+// BulkResult is the summary of a bulk operation.
+type BulkResult struct {
+	DryRun  bool
+	Scanned int
+	// Matched is the ids of selected messages, in the order they are processed.
+	Matched []string
+	// Succeeded is the ids of messages processed successfully, it is empty in dry run.
+	Succeeded []string
+	// Failed is the errors keyed by message id.
+	Failed map[string]error
+}
+
+// BulkOption is the type for optional arguments of bulk operations.
+type BulkOption func(*bulkConfig)
+
+type bulkConfig struct {
+	dryRun   bool
+	pageSize int
+	progress func(BulkProgress)
+}
+
+// BulkWithDryRun only selects messages without changing them.
+func BulkWithDryRun() BulkOption {
+	return func(c *bulkConfig) {
+		c.dryRun = true
+	}
+}
+
+// BulkWithPageSize sets the number of messages listed in a request.
+func BulkWithPageSize(size int) BulkOption {
+	return func(c *bulkConfig) {
+		c.pageSize = size
+	}
+}
+
+// BulkWithProgress sets the function called after every message is processed.
+func BulkWithProgress(f func(BulkProgress)) BulkOption {
+	return func(c *bulkConfig) {
+		c.progress = f
+	}
+}
+
+type bulkRun struct {
+	s        *Session
+	ctx      context.Context
+	config   bulkConfig
+	endpoint string
+	action   func(msgID string) error
+	result   *BulkResult
+	// listFirst lists all the selected messages before processing any, for actions which break the cursor of listing.
+	listFirst bool
+}
+
+func (s *Session) newBulkRun(ctx context.Context, endpoint string, options []BulkOption, action func(msgID string) error) *bulkRun {
+	r := &bulkRun{
+		s:        s,
+		ctx:      ctx,
+		endpoint: endpoint,
+		action:   action,
+		result:   &BulkResult{Failed: map[string]error{}},
+	}
+	for _, item := range options {
+		item(&r.config)
+	}
+	r.result.DryRun = r.config.dryRun
+	return r
+}
+
+// process runs the action on a selected message. Errors of the action are collected in the result,
+// while the error of ctx stops the operation.
+func (r *bulkRun) process(msgID string) error {
+	r.result.Matched = append(r.result.Matched, msgID)
+	if !r.config.dryRun {
+		if err := r.ctx.Err(); err != nil {
+			return err
+		}
+		if r.s.RateLimiter != nil {
+			if err := r.s.RateLimiter.Wait(r.ctx, r.s.rateLimitKey(r.endpoint)); err != nil {
+				return err
+			}
+		}
+		if err := r.action(msgID); err != nil {
+			addCaller(r.s.Logger.Warn()).Str("msg_id", msgID).Err("err", err).Msg("bulk operation failed")
+			r.result.Failed[msgID] = err
+		} else {
+			r.result.Succeeded = append(r.result.Succeeded, msgID)
+		}
+	}
+	if r.config.progress != nil {
+		r.config.progress(BulkProgress{
+			Scanned:   r.result.Scanned,
+			Matched:   len(r.result.Matched),
+			Succeeded: len(r.result.Succeeded),
+			Failed:    len(r.result.Failed),
+			MsgID:     msgID,
+		})
+	}
+	return nil
+}
+
+// scan walks the channel and processes messages selected by the filter.
+func (r *bulkRun) scan(targetID string, filter *MessageFilter) (*BulkResult, error) {
+	if filter == nil {
+		filter = &MessageFilter{}
+	}
+	options := filter.history()
+	if r.config.pageSize > 0 {
+		options = append(options, HistoryWithPageSize(r.config.pageSize))
+	}
+	it := r.s.MessageListAll(r.ctx, targetID, options...)
+	var selected []string
+	for it.Next() {
+		r.result.Scanned++
+		m := it.Item()
+		if !filter.Match(m) {
+			continue
+		}
+		selected = append(selected, m.ID)
+		if !r.listFirst {
+			if err := r.process(m.ID); err != nil {
+				return r.result, err
+			}
+		}
+		if filter.Limit > 0 && len(selected) >= filter.Limit {
+			break
+		}
+	}
+	if err := it.Err(); err != nil {
+		return r.result, err
+	}
+	if r.listFirst {
+		for _, id := range selected {
+			if err := r.process(id); err != nil {
+				return r.result, err
+			}
+		}
+	}
+	return r.result, nil
+}
+
+// MessagePurge deletes messages of a channel selected by the filter, from the newest to the oldest,
+// or from the oldest if AfterMessage is set.
+//
+// Messages are listed before any is deleted, as the cursor of listing is a message which could be deleted.
+// Failures of deleting single messages are collected in the result, the error is only returned
+// if listing messages fails or ctx is done. Use BulkWithDryRun to preview the selected messages.
+func (s *Session) MessagePurge(ctx context.Context, targetID string, filter *MessageFilter, options ...BulkOption) (*BulkResult, error) {
+	r := s.newBulkRun(ctx, EndpointMessageDelete, options, s.MessageDelete)
+	r.listFirst = true
+	return r.scan(targetID, filter)
+}
+
+// MessageBulkDelete deletes the messages.
+//
+// Failures of deleting single messages are collected in the result, the error is only returned if ctx is done.
+func (s *Session) MessageBulkDelete(ctx context.Context, msgIDs []string, options ...BulkOption) (*BulkResult, error) {
+	r := s.newBulkRun(ctx, EndpointMessageDelete, options, s.MessageDelete)
+	for _, id := range msgIDs {
+		r.result.Scanned++
+		if err := r.process(id); err != nil {
+			return r.result, err
+		}
+	}
+	return r.result, nil
+}
+
+// MessageBulkAddReaction adds the emoji reaction to messages of a channel selected by the filter.
+func (s *Session) MessageBulkAddReaction(ctx context.Context, targetID string, filter *MessageFilter, emoji string, options ...BulkOption) (*BulkResult, error) {
+	return s.newBulkRun(ctx, EndpointMessageAddReaction, options, func(msgID string) error {
+		return s.MessageAddReaction(msgID, emoji)
+	}).scan(targetID, filter)
+}
+
+// MessageBulkDeleteReaction deletes the emoji reaction of a user from messages of a channel selected by the filter.
+// An empty userID deletes the reaction of the bot.
+func (s *Session) MessageBulkDeleteReaction(ctx context.Context, targetID string, filter *MessageFilter, emoji, userID string, options ...BulkOption) (*BulkResult, error) {
+	return s.newBulkRun(ctx, EndpointMessageDeleteReaction, options, func(msgID string) error {
+		return s.MessageDeleteReaction(msgID, emoji, userID)
+	}).scan(targetID, filter)
+}
@@ -0,0 +1,155 @@
+package kook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newPurgeTestServer lists messages 1 to n like newHistoryTestServer, except that deleted messages are gone,
+// and listing next to a deleted message fails as kook does.
+func newPurgeTestServer(t *testing.T, n int) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var deleted []string
+	gone := map[int]bool{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if strings.HasSuffix(r.URL.Path, "/message/delete") {
+			body := struct {
+				MsgID string `json:"msg_id"`
+			}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			id, _ := strconv.Atoi(body.MsgID)
+			gone[id] = true
+			deleted = append(deleted, body.MsgID)
+			w.Write([]byte(`{"code":0,"message":"","data":[]}`))
+			return
+		}
+		q := r.URL.Query()
+		size, _ := strconv.Atoi(q.Get("page_size"))
+		ref, _ := strconv.Atoi(q.Get("msg_id"))
+		if q.Get("msg_id") != "" && (ref < 1 || ref > n || gone[ref]) {
+			w.Write([]byte(`{"code":40000,"message":"message not found","data":[]}`))
+			return
+		}
+		var remaining []int
+		for i := 1; i <= n; i++ {
+			if !gone[i] {
+				remaining = append(remaining, i)
+			}
+		}
+		var page []int
+		switch q.Get("flag") {
+		case "after":
+			for _, i := range remaining {
+				if i >= ref && len(page) < size {
+					page = append(page, i)
+				}
+			}
+		default:
+			for _, i := range remaining {
+				if q.Get("flag") != "before" || i <= ref {
+					page = append(page, i)
+				}
+			}
+			if len(page) > size {
+				page = page[len(page)-size:]
+			}
+		}
+		var items []*DetailedChannelMessage
+		for _, i := range page {
+			items = append(items, &DetailedChannelMessage{ID: strconv.Itoa(i), CreateAt: MilliTimeStamp(i * 1000)})
+		}
+		data, _ := json.Marshal(map[string]interface{}{"items": items})
+		json.NewEncoder(w).Encode(EndpointGeneralResponse{Data: data})
+	}))
+	return ts, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), deleted...)
+	}
+}
+
+func TestMessagePurge(t *testing.T) {
+	ts, deleted := newPurgeTestServer(t, 10)
+	defer ts.Close()
+	s := New("", mockLogger{}, SessionWithAPIBase(ts.URL))
+	filter := &MessageFilter{Content: regexp.MustCompile(`^$`), Limit: 3}
+	res, err := s.MessagePurge(context.Background(), "c", filter, BulkWithPageSize(4), BulkWithDryRun())
+	if err != nil || len(deleted()) != 0 || strings.Join(res.Matched, ",") != "10,9,8" {
+		t.Error(res, err)
+	}
+	var progress int
+	filter = &MessageFilter{AfterMessage: "7"}
+	res, err = s.MessagePurge(context.Background(), "c", filter, BulkWithPageSize(4), BulkWithProgress(func(p BulkProgress) {
+		progress = p.Succeeded
+	}))
+	if get := strings.Join(deleted(), ","); err != nil || get != "8,9,10" || len(res.Succeeded) != 3 || progress != 3 {
+		t.Error(res, get, err)
+	}
+	res, err = s.MessagePurge(context.Background(), "c", nil, BulkWithPageSize(3))
+	if get := strings.Join(deleted(), ","); err != nil || get != "8,9,10,7,6,5,4,3,2,1" || len(res.Failed) != 0 {
+		t.Error(res, get, err)
+	}
+}
+
+func TestMessageBulkReaction(t *testing.T) {
+	purge, _ := newPurgeTestServer(t, 10)
+	defer purge.Close()
+	var mu sync.Mutex
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "-reaction") {
+			purge.Config.Handler.ServeHTTP(w, r)
+			return
+		}
+		body := struct {
+			MsgID  string `json:"msg_id"`
+			Emoji  string `json:"emoji"`
+			UserID string `json:"user_id"`
+		}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		requests = append(requests, strings.TrimSpace(strings.Join([]string{r.URL.Path[len("/v3/message/"):], body.MsgID, body.Emoji, body.UserID}, " ")))
+		mu.Unlock()
+		if body.MsgID == "9" {
+			w.Write([]byte(`{"code":40000,"message":"message not found","data":[]}`))
+			return
+		}
+		w.Write([]byte(`{"code":0,"message":"","data":[]}`))
+	}))
+	defer ts.Close()
+	s := New("", mockLogger{}, SessionWithAPIBase(ts.URL))
+	filter := &MessageFilter{Limit: 3}
+	var progress BulkProgress
+	res, err := s.MessageBulkAddReaction(context.Background(), "c", filter, "👍", BulkWithPageSize(4), BulkWithProgress(func(p BulkProgress) {
+		progress = p
+	}))
+	if get := strings.Join(requests, ","); err != nil || get != "add-reaction 10 👍,add-reaction 9 👍,add-reaction 8 👍" {
+		t.Error(get, err)
+	}
+	var restErr *RestError
+	if strings.Join(res.Succeeded, ",") != "10,8" || len(res.Failed) != 1 || !errors.As(res.Failed["9"], &restErr) || restErr.Code != 40000 {
+		t.Error(res)
+	}
+	if progress.Matched != 3 || progress.Succeeded != 2 || progress.Failed != 1 || progress.MsgID != "8" {
+		t.Error(progress)
+	}
+
+	requests = nil
+	res, err = s.MessageBulkDeleteReaction(context.Background(), "c", &MessageFilter{AfterMessage: "7"}, "👍", "u1", BulkWithPageSize(4))
+	if get := strings.Join(requests, ","); err != nil || get != "delete-reaction 8 👍 u1,delete-reaction 9 👍 u1,delete-reaction 10 👍 u1" {
+		t.Error(get, err)
+	}
+	if strings.Join(res.Succeeded, ",") != "8,10" || len(res.Failed) != 1 || res.Failed["9"] == nil {
+		t.Error(res)
+	}
+}
@@ -0,0 +1,285 @@
+package kook
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CardModule is the type for modules of a card, implemented by *CardMessageHeader, *CardMessageSection,
+// *CardMessageImageGroup, *CardMessageContainer, *CardMessageActionGroup, *CardMessageContext,
+// *CardMessageDivider, *CardMessageFile, *CardMessageCountdown and *CardMessageInvite.
+type CardModule interface {
+	cardModule()
+}
+
+// CardSectionText is the type for text of CardMessageSection, implemented by *CardMessageElementText,
+// *CardMessageElementKMarkdown and *CardMessageParagraph.
+type CardSectionText interface {
+	cardSectionText()
+}
+
+// CardSectionAccessory is the type for accessory of CardMessageSection, implemented by *CardMessageElementImage
+// and *CardMessageElementButton.
+type CardSectionAccessory interface {
+	cardSectionAccessory()
+}
+
+// CardContextElement is the type for elements of CardMessageContext, implemented by *CardMessageElementText,
+// *CardMessageElementKMarkdown and *CardMessageElementImage.
+type CardContextElement interface {
+	cardContextElement()
+}
+
+// CardParagraphField is the type for fields of CardMessageParagraph, implemented by *CardMessageElementText
+// and *CardMessageElementKMarkdown.
+type CardParagraphField interface {
+	cardParagraphField()
+}
+
+func (c *CardMessageHeader) cardModule()      {}
+func (c *CardMessageSection) cardModule()     {}
+func (c *CardMessageImageGroup) cardModule()  {}
+func (c *CardMessageContainer) cardModule()   {}
+func (c *CardMessageActionGroup) cardModule() {}
+func (c *CardMessageContext) cardModule()     {}
+func (c *CardMessageDivider) cardModule()     {}
+func (c *CardMessageFile) cardModule()        {}
+func (c *CardMessageCountdown) cardModule()   {}
+func (c *CardMessageInvite) cardModule()      {}
+
+func (c *CardMessageElementText) cardSectionText()      {}
+func (c *CardMessageElementKMarkdown) cardSectionText() {}
+func (c *CardMessageParagraph) cardSectionText()        {}
+
+func (c *CardMessageElementImage) cardSectionAccessory()  {}
+func (c *CardMessageElementButton) cardSectionAccessory() {}
+
+func (c *CardMessageElementText) cardContextElement()      {}
+func (c *CardMessageElementKMarkdown) cardContextElement() {}
+func (c *CardMessageElementImage) cardContextElement()     {}
+
+func (c *CardMessageElementText) cardParagraphField()      {}
+func (c *CardMessageElementKMarkdown) cardParagraphField() {}
+
+// isNilCardItem reports whether the module or element is nil, including typed nil pointers.
+func isNilCardItem(i interface{}) bool {
+	if i == nil {
+		return true
+	}
+	v := reflect.ValueOf(i)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// CardBuilder is a fluent builder of card messages, with modules and elements checked at compile time.
+// Instead of panicking, errors are accumulated and returned by Build, together with the result of
+// CardMessage.Validate.
+//
+//	msg, err := kook.NewCardBuilder().
+//		Card(kook.CardThemePrimary, kook.CardSizeLg).
+//		Header("Hello").
+//		Section(kook.NewKMarkdown("**world**"), kook.NewButton(kook.CardThemePrimary, "ok", "OK")).
+//		Divider().
+//		Message()
+type CardBuilder struct {
+	cards CardMessage
+	errs  CardValidationErrors
+}
+
+// NewCardBuilder creates a CardBuilder.
+func NewCardBuilder() *CardBuilder {
+	return &CardBuilder{}
+}
+
+func (b *CardBuilder) fail(path, format string, args ...interface{}) {
+	b.errs = append(b.errs, &CardValidationError{Path: path, Reason: fmt.Sprintf(format, args...)})
+}
+
+// current returns the card modules are added to, which is started if there is none.
+func (b *CardBuilder) current() *CardMessageCard {
+	if len(b.cards) == 0 {
+		b.Card("", "")
+	}
+	return b.cards[len(b.cards)-1]
+}
+
+func (b *CardBuilder) path() string {
+	return fmt.Sprintf("[%d].modules[%d]", len(b.cards)-1, len(b.current().Modules))
+}
+
+// Card starts a new card, to which the following modules are added. Empty theme or size uses the default.
+func (b *CardBuilder) Card(theme CardTheme, size CardSize) *CardBuilder {
+	b.cards = append(b.cards, &CardMessageCard{Theme: theme, Size: size, Modules: []interface{}{}})
+	return b
+}
+
+// Color sets the color of the current card, e.g. "#aaaaaa".
+func (b *CardBuilder) Color(color string) *CardBuilder {
+	b.current().Color = color
+	return b
+}
+
+// Module adds modules to the current card.
+func (b *CardBuilder) Module(modules ...CardModule) *CardBuilder {
+	c := b.current()
+	for _, item := range modules {
+		if isNilCardItem(item) {
+			b.fail(b.path(), "nil module")
+			continue
+		}
+		c.Modules = append(c.Modules, item)
+	}
+	return b
+}
+
+// Header adds a header module with the plain text.
+func (b *CardBuilder) Header(text string) *CardBuilder {
+	return b.Module(NewHeader(text))
+}
+
+// Section adds a section module, the accessory could be nil.
+func (b *CardBuilder) Section(text CardSectionText, accessory CardSectionAccessory) *CardBuilder {
+	if isNilCardItem(text) {
+		b.fail(b.path()+".text", "nil text")
+		return b
+	}
+	s := &CardMessageSection{Text: text}
+	if !isNilCardItem(accessory) {
+		s.Accessory = accessory
+		if _, ok := accessory.(*CardMessageElementButton); ok {
+			s.Mode = CardMessageSectionModeRight
+		}
+	}
+	return b.Module(s)
+}
+
+// SectionWithMode adds a section module with the accessory placed on the side of mode.
+func (b *CardBuilder) SectionWithMode(mode CardMessageSectionMode, text CardSectionText, accessory CardSectionAccessory) *CardBuilder {
+	n := len(b.current().Modules)
+	b.Section(text, accessory)
+	if modules := b.current().Modules; len(modules) > n {
+		modules[n].(*CardMessageSection).Mode = mode
+	}
+	return b
+}
+
+// Text adds a section module with the plain text.
+func (b *CardBuilder) Text(content string) *CardBuilder {
+	return b.Section(NewPlainText(content), nil)
+}
+
+// KMarkdown adds a section module with the kmarkdown text.
+func (b *CardBuilder) KMarkdown(content string) *CardBuilder {
+	return b.Section(NewKMarkdown(content), nil)
+}
+
+// Paragraph adds a section module with the fields in cols columns.
+func (b *CardBuilder) Paragraph(cols int, fields ...CardParagraphField) *CardBuilder {
+	p := &CardMessageParagraph{Cols: cols, Fields: []interface{}{}}
+	for i, item := range fields {
+		if isNilCardItem(item) {
+			b.fail(fmt.Sprintf("%s.text.fields[%d]", b.path(), i), "nil field")
+			continue
+		}
+		p.Fields = append(p.Fields, item)
+	}
+	return b.Section(p, nil)
+}
+
+// Images adds an image group module.
+func (b *CardBuilder) Images(images ...*CardMessageElementImage) *CardBuilder {
+	g := CardMessageImageGroup(b.images(images))
+	return b.Module(&g)
+}
+
+// Container adds a container module, whose images are displayed in their original size.
+func (b *CardBuilder) Container(images ...*CardMessageElementImage) *CardBuilder {
+	c := CardMessageContainer(b.images(images))
+	return b.Module(&c)
+}
+
+func (b *CardBuilder) images(images []*CardMessageElementImage) []CardMessageElementImage {
+	r := make([]CardMessageElementImage, 0, len(images))
+	for i, item := range images {
+		if item == nil {
+			b.fail(fmt.Sprintf("%s.elements[%d]", b.path(), i), "nil image")
+			continue
+		}
+		r = append(r, *item)
+	}
+	return r
+}
+
+// Buttons adds an action group module.
+func (b *CardBuilder) Buttons(buttons ...*CardMessageElementButton) *CardBuilder {
+	g := make(CardMessageActionGroup, 0, len(buttons))
+	for i, item := range buttons {
+		if item == nil {
+			b.fail(fmt.Sprintf("%s.elements[%d]", b.path(), i), "nil button")
+			continue
+		}
+		g = append(g, *item)
+	}
+	return b.Module(&g)
+}
+
+// Context adds a context module.
+func (b *CardBuilder) Context(elements ...CardContextElement) *CardBuilder {
+	c := CardMessageContext{}
+	for i, item := range elements {
+		if isNilCardItem(item) {
+			b.fail(fmt.Sprintf("%s.elements[%d]", b.path(), i), "nil element")
+			continue
+		}
+		c = append(c, item)
+	}
+	return b.Module(&c)
+}
+
+// Divider adds a divider module.
+func (b *CardBuilder) Divider() *CardBuilder {
+	return b.Module(NewDivider())
+}
+
+// File adds a file, audio or video module.
+func (b *CardBuilder) File(typ CardMessageFileType, src, title string) *CardBuilder {
+	return b.Module(&CardMessageFile{Type: typ, Src: src, Title: title})
+}
+
+// Countdown adds a countdown module.
+func (b *CardBuilder) Countdown(mode CardMessageCountdownMode, start, end MilliTimeStamp) *CardBuilder {
+	return b.Module(&CardMessageCountdown{Mode: mode, StartTime: start, EndTime: end})
+}
+
+// Invite adds an invite module.
+func (b *CardBuilder) Invite(code string) *CardBuilder {
+	return b.Module(NewInvite(code))
+}
+
+// Err returns the errors accumulated so far, which does not validate the cards.
+func (b *CardBuilder) Err() error {
+	if len(b.errs) != 0 {
+		return b.errs
+	}
+	return nil
+}
+
+// Build returns the card message, with errors accumulated and found by CardMessage.Validate.
+func (b *CardBuilder) Build() (CardMessage, error) {
+	errs := append(CardValidationErrors{}, b.errs...)
+	if err := b.cards.Validate(); err != nil {
+		errs = append(errs, err.(CardValidationErrors)...)
+	}
+	if len(errs) != 0 {
+		return b.cards, errs
+	}
+	return b.cards, nil
+}
+
+// Message builds and marshals the card message for sending.
+func (b *CardBuilder) Message() (string, error) {
+	c, err := b.Build()
+	if err != nil {
+		return "", err
+	}
+	return c.BuildMessage()
+}
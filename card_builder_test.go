@@ -0,0 +1,54 @@
+package kook
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCardBuilder(t *testing.T) {
+	get, err := NewCardBuilder().
+		Card(CardThemePrimary, CardSizeLg).
+		Header("title").
+		Section(NewKMarkdown("**hi**"), NewButton(CardThemeDanger, "ok", "OK")).
+		Paragraph(2, NewPlainText("a"), NewKMarkdown("b")).
+		Divider().
+		Images(NewImage("https://img")).
+		Context(NewPlainText("note"), NewImage("https://img")).
+		Message()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := CardMessage{(&CardMessageCard{Theme: CardThemePrimary, Size: CardSizeLg}).AddModule(
+		&CardMessageHeader{Text: CardMessageElementText{Content: "title"}},
+		(&CardMessageSection{Mode: CardMessageSectionModeRight}).
+			SetText(&CardMessageElementKMarkdown{Content: "**hi**"}).
+			SetAccessory(&CardMessageElementButton{Theme: CardThemeDanger, Value: "ok", Text: "OK", Click: "return-val"}),
+		(&CardMessageSection{}).SetText((&CardMessageParagraph{Cols: 2}).AddField(
+			&CardMessageElementText{Content: "a"}, &CardMessageElementKMarkdown{Content: "b"})),
+		&CardMessageDivider{},
+		&CardMessageImageGroup{{Src: "https://img"}},
+		(&CardMessageContext{}).AddItem(&CardMessageElementText{Content: "note"}, &CardMessageElementImage{Src: "https://img"}),
+	)}.MustBuildMessage()
+	if get != expected {
+		t.Error(get, expected)
+	}
+}
+
+func TestCardBuilder_Errors(t *testing.T) {
+	var header *CardMessageHeader
+	_, err := NewCardBuilder().
+		Module(header).
+		Section(nil, nil).
+		Buttons(NewButton(CardThemePrimary, "1", "1"), nil).
+		Build()
+	errs, ok := err.(CardValidationErrors)
+	if !ok || len(errs) != 3 {
+		t.Fatal(err)
+	}
+	if errs[0].Path != "[0].modules[0]" || errs[1].Path != "[0].modules[0].text" || errs[2].Path != "[0].modules[0].elements[1]" {
+		t.Error(errs)
+	}
+	if !errors.Is(err, ErrInvalidCard) {
+		t.Error(err)
+	}
+}
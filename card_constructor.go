@@ -0,0 +1,148 @@
+package kook
+
+import "time"
+
+// NewPlainText creates a plain text element.
+func NewPlainText(content string) *CardMessageElementText {
+	return &CardMessageElementText{Content: content}
+}
+
+// NewKMarkdown creates a kmarkdown element.
+func NewKMarkdown(content string) *CardMessageElementKMarkdown {
+	return &CardMessageElementKMarkdown{Content: content}
+}
+
+// NewImage creates an image element.
+func NewImage(src string) *CardMessageElementImage {
+	return &CardMessageElementImage{Src: src}
+}
+
+// NewButton creates a button element returning the value when clicked, in a MessageButtonClickContext event.
+func NewButton(theme CardTheme, value, text string) *CardMessageElementButton {
+	return &CardMessageElementButton{Theme: theme, Value: value, Text: text, Click: string(CardMessageElementButtonClickReturnVal)}
+}
+
+// NewLinkButton creates a button element opening the url when clicked.
+func NewLinkButton(theme CardTheme, url, text string) *CardMessageElementButton {
+	return &CardMessageElementButton{Theme: theme, Value: url, Text: text, Click: string(CardMessageElementButtonClickLink)}
+}
+
+// NewParagraph creates a paragraph element with the fields in cols columns. Nil fields are skipped.
+func NewParagraph(cols int, fields ...CardParagraphField) *CardMessageParagraph {
+	p := &CardMessageParagraph{Cols: cols, Fields: []interface{}{}}
+	for _, item := range fields {
+		if !isNilCardItem(item) {
+			p.Fields = append(p.Fields, item)
+		}
+	}
+	return p
+}
+
+// NewHeader creates a header module with the plain text.
+func NewHeader(text string) *CardMessageHeader {
+	return &CardMessageHeader{Text: CardMessageElementText{Content: text}}
+}
+
+// NewSection creates a section module, the accessory could be nil. Buttons are placed on the right,
+// as kook does not allow them on the left.
+func NewSection(text CardSectionText, accessory CardSectionAccessory) *CardMessageSection {
+	s := &CardMessageSection{}
+	if !isNilCardItem(text) {
+		s.Text = text
+	}
+	if !isNilCardItem(accessory) {
+		s.Accessory = accessory
+		if _, ok := accessory.(*CardMessageElementButton); ok {
+			s.Mode = CardMessageSectionModeRight
+		}
+	}
+	return s
+}
+
+// NewImageModule creates an image group module of 1 to 9 images. Nil images are skipped.
+func NewImageModule(images ...*CardMessageElementImage) *CardMessageImageGroup {
+	g := CardMessageImageGroup(derefImages(images))
+	return &g
+}
+
+// NewContainer creates a container module, whose images are displayed in their original size. Nil images are skipped.
+func NewContainer(images ...*CardMessageElementImage) *CardMessageContainer {
+	c := CardMessageContainer(derefImages(images))
+	return &c
+}
+
+func derefImages(images []*CardMessageElementImage) []CardMessageElementImage {
+	r := make([]CardMessageElementImage, 0, len(images))
+	for _, item := range images {
+		if item != nil {
+			r = append(r, *item)
+		}
+	}
+	return r
+}
+
+// NewActionGroup creates an action group module of 1 to 4 buttons. Nil buttons are skipped.
+func NewActionGroup(buttons ...*CardMessageElementButton) *CardMessageActionGroup {
+	g := make(CardMessageActionGroup, 0, len(buttons))
+	for _, item := range buttons {
+		if item != nil {
+			g = append(g, *item)
+		}
+	}
+	return &g
+}
+
+// NewContext creates a context module. Nil elements are skipped.
+func NewContext(elements ...CardContextElement) *CardMessageContext {
+	c := CardMessageContext{}
+	for _, item := range elements {
+		if !isNilCardItem(item) {
+			c = append(c, item)
+		}
+	}
+	return &c
+}
+
+// NewDivider creates a divider module.
+func NewDivider() *CardMessageDivider {
+	return &CardMessageDivider{}
+}
+
+// NewFileModule creates a file module.
+func NewFileModule(src, title string) *CardMessageFile {
+	return &CardMessageFile{Type: CardMessageFileTypeFile, Src: src, Title: title}
+}
+
+// NewAudioModule creates an audio module, the cover is optional.
+func NewAudioModule(src, title, cover string) *CardMessageFile {
+	return &CardMessageFile{Type: CardMessageFileTypeAudio, Src: src, Title: title, Cover: cover}
+}
+
+// NewVideoModule creates a video module.
+func NewVideoModule(src, title string) *CardMessageFile {
+	return &CardMessageFile{Type: CardMessageFileTypeVideo, Src: src, Title: title}
+}
+
+// NewCountdown creates a countdown module ending at end. The countdown of CardMessageCountdownModeSecond
+// starts from now, use NewSecondCountdown to specify it.
+func NewCountdown(mode CardMessageCountdownMode, end time.Time) *CardMessageCountdown {
+	c := &CardMessageCountdown{Mode: mode, EndTime: MilliTimeStampOfTime(end)}
+	if mode == CardMessageCountdownModeSecond {
+		c.StartTime = MilliTimeStampOfTime(time.Now())
+	}
+	return c
+}
+
+// NewSecondCountdown creates a countdown module of CardMessageCountdownModeSecond from start to end.
+func NewSecondCountdown(start, end time.Time) *CardMessageCountdown {
+	return &CardMessageCountdown{
+		Mode:      CardMessageCountdownModeSecond,
+		StartTime: MilliTimeStampOfTime(start),
+		EndTime:   MilliTimeStampOfTime(end),
+	}
+}
+
+// NewInvite creates an invite module of the invite code or url.
+func NewInvite(code string) *CardMessageInvite {
+	return &CardMessageInvite{Code: code}
+}
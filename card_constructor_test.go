@@ -0,0 +1,75 @@
+package kook
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// FYI: https://developer.kookapp.cn/doc/cardmessage
+func TestCardConstructors(t *testing.T) {
+	end := time.Unix(1608883200, 0)
+	cases := []struct {
+		module   interface{}
+		expected string
+	}{
+		{NewHeader("标题"), `{"type":"header","text":{"type":"plain-text","content":"标题","emoji":false}}`},
+		{NewSection(NewKMarkdown("您是否认为\"KOOK\"是最好的语音软件？"), NewLinkButton(CardThemePrimary, "https://www.kookapp.cn", "完全同意")),
+			`{"type":"section","mode":"right","text":{"type":"kmarkdown","content":"您是否认为\"KOOK\"是最好的语音软件？"},` +
+				`"accessory":{"type":"button","theme":"primary","click":"link","value":"https://www.kookapp.cn","text":{"type":"plain-text","content":"完全同意"}}}`},
+		{NewSection(NewParagraph(3, NewKMarkdown("**昵称**\nKOOK"), NewKMarkdown("**服务器**\n活动中心")), nil),
+			`{"type":"section","text":{"type":"paragraph","cols":3,"fields":[` +
+				`{"type":"kmarkdown","content":"**昵称**\nKOOK"},{"type":"kmarkdown","content":"**服务器**\n活动中心"}]}}`},
+		{NewImageModule(NewImage("https://img.kaiheila.cn/assets/2021-01/7kr4FkWpLV0ku0ku.jpeg"), nil),
+			`{"type":"image-group","elements":[{"type":"image","src":"https://img.kaiheila.cn/assets/2021-01/7kr4FkWpLV0ku0ku.jpeg"}]}`},
+		{NewContainer(NewImage("https://img.kaiheila.cn/assets/2021-01/7kr4FkWpLV0ku0ku.jpeg")),
+			`{"type":"container","elements":[{"type":"image","src":"https://img.kaiheila.cn/assets/2021-01/7kr4FkWpLV0ku0ku.jpeg"}]}`},
+		{NewActionGroup(NewButton(CardThemePrimary, "ok", "确定"), NewButton(CardThemeDanger, "cancel", "取消")),
+			`{"type":"action-group","elements":[` +
+				`{"type":"button","theme":"primary","value":"ok","click":"return-val","text":{"type":"plain-text","content":"确定"}},` +
+				`{"type":"button","theme":"danger","value":"cancel","click":"return-val","text":{"type":"plain-text","content":"取消"}}]}`},
+		{NewContext(NewPlainText("KOOK气氛组"), NewImage("https://img.kaiheila.cn/assets/2021-01/7kr4FkWpLV0ku0ku.jpeg")),
+			`{"type":"context","elements":[{"type":"plain-text","content":"KOOK气氛组","emoji":false},` +
+				`{"type":"image","src":"https://img.kaiheila.cn/assets/2021-01/7kr4FkWpLV0ku0ku.jpeg"}]}`},
+		{NewDivider(), `{"type":"divider"}`},
+		{NewFileModule("https://img.kaiheila.cn/attachments/2021-01/21/600972b5d0d31.txt", "KOOK介绍.txt"),
+			`{"type":"file","title":"KOOK介绍.txt","src":"https://img.kaiheila.cn/attachments/2021-01/21/600972b5d0d31.txt"}`},
+		{NewAudioModule("https://img.kaiheila.cn/attachments/2021-01/21/600975671b9ab.mp3", "命运交响曲", "https://img.kaiheila.cn/assets/2021-01/rcdqa8fAOO0hs0mj.jpg"),
+			`{"type":"audio","title":"命运交响曲","src":"https://img.kaiheila.cn/attachments/2021-01/21/600975671b9ab.mp3",` +
+				`"cover":"https://img.kaiheila.cn/assets/2021-01/rcdqa8fAOO0hs0mj.jpg"}`},
+		{NewVideoModule("https://img.kaiheila.cn/attachments/2021-01/20/6008127e8c8de.mp4", "有本事别笑"),
+			`{"type":"video","title":"有本事别笑","src":"https://img.kaiheila.cn/attachments/2021-01/20/6008127e8c8de.mp4"}`},
+		{NewSecondCountdown(time.Unix(1608883000, 0), end),
+			`{"type":"countdown","mode":"second","startTime":1608883000000,"endTime":1608883200000}`},
+		{NewCountdown(CardMessageCountdownModeDay, end), `{"type":"countdown","mode":"day","endTime":1608883200000}`},
+		{NewInvite("https://kook.top/EWQfwm"), `{"type":"invite","code":"https://kook.top/EWQfwm"}`},
+	}
+	for _, item := range cases {
+		get, err := json.Marshal(item.module)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var g, e interface{}
+		if err = json.Unmarshal(get, &g); err != nil {
+			t.Fatal(err)
+		}
+		if err = json.Unmarshal([]byte(item.expected), &e); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(g, e) {
+			t.Error(string(get), item.expected)
+		}
+		decoded, err := unmarshalCardItem([]byte(item.expected))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if again, _ := json.Marshal(decoded); string(again) != string(get) {
+			t.Error(string(again), string(get))
+		}
+	}
+	c := NewCountdown(CardMessageCountdownModeSecond, time.Now().Add(time.Hour))
+	if err := (CardMessage{(&CardMessageCard{}).AddModule(c)}).Validate(); err != nil {
+		t.Error(err)
+	}
+}
@@ -9,8 +9,31 @@ const unsupportedCardType = "Unsupported type."
 // CardMessage is the type for a message of cards called 卡片消息.
 type CardMessage []*CardMessageCard
 
+// CardBuildOption is the type for optional arguments of BuildMessage.
+type CardBuildOption func(*cardBuildConfig)
+
+type cardBuildConfig struct {
+	validate bool
+}
+
+// CardBuildWithValidation validates the card message before marshaling, see CardMessage.Validate.
+func CardBuildWithValidation() CardBuildOption {
+	return func(c *cardBuildConfig) {
+		c.validate = true
+	}
+}
+
 // BuildMessage is a helper function to marshal card message for sending.
-func (c CardMessage) BuildMessage() (s string, err error) {
+func (c CardMessage) BuildMessage(options ...CardBuildOption) (s string, err error) {
+	config := &cardBuildConfig{}
+	for _, item := range options {
+		item(config)
+	}
+	if config.validate {
+		if err = c.Validate(); err != nil {
+			return "", err
+		}
+	}
 	b, err := json.Marshal(c)
 	if err != nil {
 		return "", err
@@ -19,14 +42,26 @@ func (c CardMessage) BuildMessage() (s string, err error) {
 }
 
 // MustBuildMessage is a helper function to marshal card message for sending.
-func (c CardMessage) MustBuildMessage() string {
-	s, err := c.BuildMessage()
+func (c CardMessage) MustBuildMessage(options ...CardBuildOption) string {
+	s, err := c.BuildMessage(options...)
 	if err != nil {
 		panic(`kook.CardMessage.BuildMessage:` + err.Error())
 	}
 	return s
 }
 
+// ParseCardMessage decodes the content of a card message, e.g. DetailedChannelMessage.Content of MessageTypeCard.
+//
+// Modules and elements are decoded into pointers of their types, e.g. *CardMessageSection, so they could be
+// modified and sent again.
+func ParseCardMessage(content string) (CardMessage, error) {
+	var c CardMessage
+	if err := json.Unmarshal([]byte(content), &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
 // CardTheme is the type for card theme.
 type CardTheme string
 
@@ -69,10 +104,100 @@ func (c CardMessageCard) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// UnmarshalJSON decodes modules into their types by the type field.
+func (c *CardMessageCard) UnmarshalJSON(data []byte) error {
+	var v struct {
+		fakeCardMessageCard
+		Modules []json.RawMessage `json:"modules"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	modules, err := unmarshalCardItems(v.Modules)
+	if err != nil {
+		return err
+	}
+	*c = CardMessageCard(v.fakeCardMessageCard)
+	c.Modules = modules
+	return nil
+}
+
+// unmarshalCardItem decodes a module or an element into a pointer of its type by the type field.
+// Items of unknown types are kept as json.RawMessage, so they are sent back unchanged.
+func unmarshalCardItem(data []byte) (interface{}, error) {
+	var t struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	var v interface{}
+	switch t.Type {
+	case "card":
+		v = &CardMessageCard{}
+	case "header":
+		v = &CardMessageHeader{}
+	case "section":
+		v = &CardMessageSection{}
+	case "image-group":
+		v = &CardMessageImageGroup{}
+	case "container":
+		v = &CardMessageContainer{}
+	case "action-group":
+		v = &CardMessageActionGroup{}
+	case "context":
+		v = &CardMessageContext{}
+	case "divider":
+		v = &CardMessageDivider{}
+	case string(CardMessageFileTypeFile), string(CardMessageFileTypeAudio), string(CardMessageFileTypeVideo):
+		v = &CardMessageFile{}
+	case "countdown":
+		v = &CardMessageCountdown{}
+	case "invite":
+		v = &CardMessageInvite{}
+	case "plain-text":
+		v = &CardMessageElementText{}
+	case "kmarkdown":
+		v = &CardMessageElementKMarkdown{}
+	case "image":
+		v = &CardMessageElementImage{}
+	case "button":
+		v = &CardMessageElementButton{}
+	case "paragraph":
+		v = &CardMessageParagraph{}
+	default:
+		return append(json.RawMessage(nil), data...), nil
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func unmarshalCardItems(data []json.RawMessage) ([]interface{}, error) {
+	items := make([]interface{}, 0, len(data))
+	for _, item := range data {
+		v, err := unmarshalCardItem(item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, v)
+	}
+	return items, nil
+}
+
+// unmarshalOptionalCardItem decodes an optional element, which is nil if it is missing.
+func unmarshalOptionalCardItem(data json.RawMessage) (interface{}, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+	return unmarshalCardItem(data)
+}
+
 // AddModule adds Modules to a card and provides a runtime type check for CardMessageCard Modules.
 //
 // Allowed Modules: *CardMessageHeader, *CardMessageSection, *CardMessageImageGroup, *CardMessageContainer,
-// *CardMessageActionGroup, *CardMessageContext, *CardMessageDivider, *CardMessageFile, *CardMessageCountdown.
+// *CardMessageActionGroup, *CardMessageContext, *CardMessageDivider, *CardMessageFile, *CardMessageCountdown, *CardMessageInvite.
 func (c *CardMessageCard) AddModule(i ...interface{}) *CardMessageCard {
 	for _, item := range i {
 		switch v := item.(type) {
@@ -84,7 +209,8 @@ func (c *CardMessageCard) AddModule(i ...interface{}) *CardMessageCard {
 			*CardMessageContext,
 			*CardMessageDivider,
 			*CardMessageFile,
-			*CardMessageCountdown:
+			*CardMessageCountdown,
+			*CardMessageInvite:
 			c.Modules = append(c.Modules, v)
 		default:
 			panic(unsupportedCardType)
@@ -138,6 +264,30 @@ func (c CardMessageSection) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// UnmarshalJSON decodes text and accessory into their types by the type field.
+func (c *CardMessageSection) UnmarshalJSON(data []byte) error {
+	var v struct {
+		fakeCardMessageSection
+		Text      json.RawMessage `json:"text"`
+		Accessory json.RawMessage `json:"accessory"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	text, err := unmarshalOptionalCardItem(v.Text)
+	if err != nil {
+		return err
+	}
+	accessory, err := unmarshalOptionalCardItem(v.Accessory)
+	if err != nil {
+		return err
+	}
+	*c = CardMessageSection(v.fakeCardMessageSection)
+	c.Text = text
+	c.Accessory = accessory
+	return nil
+}
+
 // SetText provides additional type-checking when setting elements to section text.
 //
 // Allowed elements: *CardMessageElementText, *CardMessageElementKMarkdown, *CardMessageParagraph.
@@ -177,6 +327,18 @@ func (c CardMessageImageGroup) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// UnmarshalJSON decodes elements of the module.
+func (c *CardMessageImageGroup) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Elements []CardMessageElementImage `json:"elements"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*c = v.Elements
+	return nil
+}
+
 // CardMessageActionGroup is the type for 模块-交互模块.
 type CardMessageActionGroup []CardMessageElementButton
 
@@ -190,6 +352,18 @@ func (c CardMessageActionGroup) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// UnmarshalJSON decodes elements of the module.
+func (c *CardMessageActionGroup) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Elements []CardMessageElementButton `json:"elements"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*c = v.Elements
+	return nil
+}
+
 // CardMessageContext is the type for 模块-备注模块.
 type CardMessageContext []interface{}
 
@@ -203,6 +377,22 @@ func (c CardMessageContext) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// UnmarshalJSON decodes elements of the module into their types by the type field.
+func (c *CardMessageContext) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Elements []json.RawMessage `json:"elements"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	elements, err := unmarshalCardItems(v.Elements)
+	if err != nil {
+		return err
+	}
+	*c = elements
+	return nil
+}
+
 // AddItem provides additional type-checking when adding elements to context.
 //
 // Allowed elements: *CardMessageElementText, *CardMessageElementKMarkdown, *CardMessageElementImage
@@ -254,7 +444,7 @@ const (
 // CardMessageCountdown is the type for 模块-倒计时模块.
 type CardMessageCountdown struct {
 	EndTime   MilliTimeStamp           `json:"endTime"`
-	StartTime MilliTimeStamp           `json:"startTime"`
+	StartTime MilliTimeStamp           `json:"startTime,omitempty"`
 	Mode      CardMessageCountdownMode `json:"mode"`
 }
 
@@ -291,6 +481,18 @@ func (c CardMessageContainer) MarshalJSON() ([]byte, error) {
 	}{"container", c})
 }
 
+// UnmarshalJSON decodes elements of the module.
+func (c *CardMessageContainer) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Elements []CardMessageElementImage `json:"elements"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*c = v.Elements
+	return nil
+}
+
 // AddElements adds elements to container.
 func (c *CardMessageContainer) AddElements(i ...CardMessageElementImage) *CardMessageContainer {
 	*c = append(*c, i...)
@@ -309,7 +511,7 @@ func (c CardMessageInvite) MarshalJSON() ([]byte, error) {
 	return json.Marshal(struct {
 		Type string `json:"type"`
 		fakeCardMessageInvite
-	}{"container", fakeCardMessageInvite(c)})
+	}{"invite", fakeCardMessageInvite(c)})
 }
 
 // CardMessageElementText is the type for 元素-普通文本.
@@ -352,7 +554,7 @@ type CardMessageElementImage struct {
 	Src    string `json:"src"`
 	Alt    string `json:"alt,omitempty"`
 	Size   string `json:"size,omitempty"`
-	Circle bool   `json:"circle"`
+	Circle bool   `json:"circle,omitempty"`
 }
 
 type fakeCardMessageElementImage CardMessageElementImage
@@ -373,6 +575,8 @@ type CardMessageElementButton struct {
 	Value string    `json:"value"`
 	Click string    `json:"click,omitempty"`
 	Text  string    `json:"text"`
+	// KMarkdown marks Text as a kmarkdown element instead of plain text.
+	KMarkdown bool `json:"-"`
 }
 
 // CardMessageElementButtonClick is the type for click modes of CardMessageElementButton
@@ -386,16 +590,52 @@ const (
 
 type fakeCardMessageElementButton CardMessageElementButton
 
+// cardMessageButtonText is the text element of buttons, without emoji so that kook applies its default.
+type cardMessageButtonText struct {
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
 // MarshalJSON adds additional type field when marshaling
 func (c CardMessageElementButton) MarshalJSON() ([]byte, error) {
+	text := cardMessageButtonText{Type: "plain-text", Content: c.Text}
+	if c.KMarkdown {
+		text.Type = "kmarkdown"
+	}
 	return json.Marshal(struct {
 		Type string `json:"type"`
 		fakeCardMessageElementButton
+		Text cardMessageButtonText `json:"text"`
 	}{
-		"button", fakeCardMessageElementButton(c),
+		"button", fakeCardMessageElementButton(c), text,
 	})
 }
 
+// UnmarshalJSON accepts the text of button as either a string or a text element.
+func (c *CardMessageElementButton) UnmarshalJSON(data []byte) error {
+	var v struct {
+		fakeCardMessageElementButton
+		Text json.RawMessage `json:"text"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*c = CardMessageElementButton(v.fakeCardMessageElementButton)
+	if len(v.Text) == 0 || string(v.Text) == "null" {
+		return nil
+	}
+	if v.Text[0] == '"' {
+		return json.Unmarshal(v.Text, &c.Text)
+	}
+	var text cardMessageButtonText
+	if err := json.Unmarshal(v.Text, &text); err != nil {
+		return err
+	}
+	c.Text = text.Content
+	c.KMarkdown = text.Type == "kmarkdown"
+	return nil
+}
+
 // CardMessageParagraph is the type for 结构体-区域文本.
 type CardMessageParagraph struct {
 	Cols   int           `json:"cols"`
@@ -414,6 +654,24 @@ func (c CardMessageParagraph) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// UnmarshalJSON decodes fields into their types by the type field.
+func (c *CardMessageParagraph) UnmarshalJSON(data []byte) error {
+	var v struct {
+		fakeCardMessageParagraph
+		Fields []json.RawMessage `json:"fields"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	fields, err := unmarshalCardItems(v.Fields)
+	if err != nil {
+		return err
+	}
+	*c = CardMessageParagraph(v.fakeCardMessageParagraph)
+	c.Fields = fields
+	return nil
+}
+
 // AddField provides additional type-checking when adding elements to paragraph.
 //
 // Allowed elements: *CardMessageElementText, *CardMessageElementKMarkdown
@@ -0,0 +1,112 @@
+package kook
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseCardMessage(t *testing.T) {
+	content := `[{"type":"card","theme":"secondary","size":"lg","modules":[` +
+		`{"type":"header","text":{"type":"plain-text","content":"title","emoji":false}},` +
+		`{"type":"section","mode":"right","text":{"type":"kmarkdown","content":"**hi**"},` +
+		`"accessory":{"type":"button","theme":"primary","value":"ok","click":"return-val","text":{"type":"plain-text","content":"OK"}}},` +
+		`{"type":"section","text":{"type":"paragraph","cols":2,"fields":[{"type":"kmarkdown","content":"a"},{"type":"plain-text","content":"b","emoji":true}]}},` +
+		`{"type":"action-group","elements":[{"type":"button","theme":"danger","value":"no","text":"No"}]},` +
+		`{"type":"context","elements":[{"type":"image","src":"https://img","circle":false}]},` +
+		`{"type":"divider"},` +
+		`{"type":"invite","code":"abc"},` +
+		`{"type":"audio","src":"https://audio","title":"song"},` +
+		`{"type":"countdown","endTime":2000,"startTime":1000,"mode":"second"},` +
+		`{"type":"unknown-module","foo":1}]}]`
+	c, err := ParseCardMessage(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c) != 1 || c[0].Theme != CardThemeSecondary || len(c[0].Modules) != 10 {
+		t.Fatal(c)
+	}
+	m := c[0].Modules
+	if h, ok := m[0].(*CardMessageHeader); !ok || h.Text.Content != "title" {
+		t.Error(m[0])
+	}
+	s, ok := m[1].(*CardMessageSection)
+	if !ok {
+		t.Fatal(m[1])
+	}
+	if k, ok := s.Text.(*CardMessageElementKMarkdown); !ok || k.Content != "**hi**" {
+		t.Error(s.Text)
+	}
+	b, ok := s.Accessory.(*CardMessageElementButton)
+	if !ok || b.Text != "OK" || b.Click != "return-val" || b.KMarkdown {
+		t.Error(s.Accessory)
+	}
+	if p, ok := m[2].(*CardMessageSection).Text.(*CardMessageParagraph); !ok || p.Cols != 2 || len(p.Fields) != 2 {
+		t.Error(m[2])
+	} else if e, ok := p.Fields[1].(*CardMessageElementText); !ok || !e.Emoji {
+		t.Error(p.Fields[1])
+	}
+	if a, ok := m[3].(*CardMessageActionGroup); !ok || len(*a) != 1 || (*a)[0].Text != "No" {
+		t.Error(m[3])
+	}
+	if x, ok := m[4].(*CardMessageContext); !ok || len(*x) != 1 {
+		t.Error(m[4])
+	} else if _, ok := (*x)[0].(*CardMessageElementImage); !ok {
+		t.Error((*x)[0])
+	}
+	if _, ok := m[5].(*CardMessageDivider); !ok {
+		t.Error(m[5])
+	}
+	if i, ok := m[6].(*CardMessageInvite); !ok || i.Code != "abc" {
+		t.Error(m[6])
+	}
+	if f, ok := m[7].(*CardMessageFile); !ok || f.Type != CardMessageFileTypeAudio {
+		t.Error(m[7])
+	}
+	if d, ok := m[8].(*CardMessageCountdown); !ok || d.EndTime != 2000 {
+		t.Error(m[8])
+	}
+	if _, ok := m[9].(json.RawMessage); !ok {
+		t.Error(m[9])
+	}
+
+	b.Theme = CardThemeSecondary
+	out, err := c.BuildMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := ParseCardMessage(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get := again[0].Modules[1].(*CardMessageSection).Accessory.(*CardMessageElementButton).Theme; get != CardThemeSecondary {
+		t.Error(get)
+	}
+	if get := again[0].Modules[9].(json.RawMessage); string(get) != `{"type":"unknown-module","foo":1}` {
+		t.Error(string(get))
+	}
+}
+
+func TestCardMessageInvite_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(CardMessageInvite{Code: "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get := string(b); get != `{"type":"invite","code":"abc"}` {
+		t.Error(get)
+	}
+}
+
+func TestCardMessageElementButton_KMarkdown(t *testing.T) {
+	content := `{"type":"button","theme":"primary","value":"ok","text":{"type":"kmarkdown","content":"**OK**"}}`
+	var b CardMessageElementButton
+	if err := json.Unmarshal([]byte(content), &b); err != nil {
+		t.Fatal(err)
+	}
+	if !b.KMarkdown || b.Text != "**OK**" {
+		t.Error(b)
+	}
+	get, err := json.Marshal(b)
+	if err != nil || string(get) != content {
+		t.Error(string(get), err)
+	}
+}
@@ -0,0 +1,369 @@
+package kook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidCard is the error matched by card validation errors with errors.Is.
+var ErrInvalidCard = errors.New("invalid card message")
+
+// These are the limits of card messages.
+// FYI: https://developer.kookapp.cn/doc/cardmessage
+const (
+	CardMaxCards           = 5
+	CardMaxModules         = 50
+	CardMaxImages          = 9
+	CardMaxButtons         = 4
+	CardMaxContextElements = 10
+	CardMaxParagraphCols   = 3
+	CardMaxParagraphFields = 50
+	CardMaxHeaderLength    = 100
+	CardMaxPlainTextLength = 2000
+	CardMaxKMarkdownLength = 5000
+)
+
+// CardValidationError is a violation of card limits, located by the path in the card message,
+// e.g. "[0].modules[2].accessory".
+type CardValidationError struct {
+	Path   string
+	Reason string
+}
+
+// Error implements error.
+func (e *CardValidationError) Error() string {
+	return "kook: card message" + e.Path + ": " + e.Reason
+}
+
+// Unwrap returns ErrInvalidCard.
+func (e *CardValidationError) Unwrap() error {
+	return ErrInvalidCard
+}
+
+// CardValidationErrors is all violations found in a card message.
+type CardValidationErrors []*CardValidationError
+
+// Error implements error.
+func (e CardValidationErrors) Error() string {
+	s := make([]string, 0, len(e))
+	for _, item := range e {
+		s = append(s, item.Error())
+	}
+	return strings.Join(s, "; ")
+}
+
+// Is matches ErrInvalidCard.
+func (e CardValidationErrors) Is(target error) bool {
+	return target == ErrInvalidCard
+}
+
+// Validate checks the card message against the limits of kook, so malformed cards fail locally
+// instead of being rejected by the server. The error is CardValidationErrors if any is found.
+func (c CardMessage) Validate() error {
+	v := &cardValidator{}
+	if len(c) == 0 {
+		v.fail("", "no cards")
+	}
+	if len(c) > CardMaxCards {
+		v.fail("", "more than %d cards", CardMaxCards)
+	}
+	modules := 0
+	for i, card := range c {
+		path := fmt.Sprintf("[%d]", i)
+		if card == nil {
+			v.fail(path, "nil card")
+			continue
+		}
+		modules += len(card.Modules)
+		v.card(path, card)
+	}
+	if modules > CardMaxModules {
+		v.fail("", "more than %d modules", CardMaxModules)
+	}
+	if len(v.errs) != 0 {
+		return v.errs
+	}
+	return nil
+}
+
+type cardValidator struct {
+	errs CardValidationErrors
+}
+
+func (v *cardValidator) fail(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, &CardValidationError{Path: path, Reason: fmt.Sprintf(format, args...)})
+}
+
+func (v *cardValidator) card(path string, c *CardMessageCard) {
+	v.theme(path+".theme", c.Theme)
+	if c.Size != "" && c.Size != CardSizeSm && c.Size != CardSizeLg {
+		v.fail(path+".size", "unknown size %q", c.Size)
+	}
+	if len(c.Modules) == 0 {
+		v.fail(path+".modules", "no modules")
+	}
+	for i, item := range c.Modules {
+		v.module(fmt.Sprintf("%s.modules[%d]", path, i), item)
+	}
+}
+
+func (v *cardValidator) module(path string, i interface{}) {
+	if isNilCardItem(i) {
+		v.fail(path, "nil module")
+		return
+	}
+	switch m := i.(type) {
+	case *CardMessageHeader:
+		v.text(path+".text", &m.Text, CardMaxHeaderLength)
+	case CardMessageHeader:
+		v.module(path, &m)
+	case *CardMessageSection:
+		v.section(path, m)
+	case CardMessageSection:
+		v.module(path, &m)
+	case *CardMessageImageGroup:
+		v.images(path, *m)
+	case CardMessageImageGroup:
+		v.images(path, m)
+	case *CardMessageContainer:
+		v.images(path, *m)
+	case CardMessageContainer:
+		v.images(path, m)
+	case *CardMessageActionGroup:
+		v.buttons(path, *m)
+	case CardMessageActionGroup:
+		v.buttons(path, m)
+	case *CardMessageContext:
+		v.context(path, *m)
+	case CardMessageContext:
+		v.context(path, m)
+	case *CardMessageDivider, CardMessageDivider:
+	case *CardMessageFile:
+		v.file(path, m)
+	case CardMessageFile:
+		v.file(path, &m)
+	case *CardMessageCountdown:
+		v.countdown(path, m)
+	case CardMessageCountdown:
+		v.countdown(path, &m)
+	case *CardMessageInvite:
+		if m.Code == "" {
+			v.fail(path+".code", "empty invite code")
+		}
+	case CardMessageInvite:
+		v.module(path, &m)
+	case json.RawMessage:
+		// Modules of unknown types decoded by UnmarshalJSON are left to the server.
+	default:
+		v.fail(path, "unsupported module %T", i)
+	}
+}
+
+func (v *cardValidator) section(path string, m *CardMessageSection) {
+	if m.Mode != "" && m.Mode != CardMessageSectionModeLeft && m.Mode != CardMessageSectionModeRight {
+		v.fail(path+".mode", "unknown mode %q", m.Mode)
+	}
+	if isNilCardItem(m.Text) {
+		v.fail(path+".text", "no text")
+	} else {
+		v.sectionText(path+".text", m.Text)
+	}
+	if isNilCardItem(m.Accessory) {
+		return
+	}
+	switch a := m.Accessory.(type) {
+	case *CardMessageElementImage:
+		v.image(path+".accessory", a)
+	case CardMessageElementImage:
+		v.image(path+".accessory", &a)
+	case *CardMessageElementButton:
+		v.sectionButton(path, m.Mode, a)
+	case CardMessageElementButton:
+		v.sectionButton(path, m.Mode, &a)
+	case nil:
+	default:
+		v.fail(path+".accessory", "unsupported element %T", m.Accessory)
+	}
+}
+
+func (v *cardValidator) sectionText(path string, text interface{}) {
+	switch t := text.(type) {
+	case *CardMessageElementText:
+		v.text(path, t, CardMaxPlainTextLength)
+	case CardMessageElementText:
+		v.text(path, &t, CardMaxPlainTextLength)
+	case *CardMessageElementKMarkdown:
+		v.kmarkdown(path, t)
+	case CardMessageElementKMarkdown:
+		v.kmarkdown(path, &t)
+	case *CardMessageParagraph:
+		v.paragraph(path, t)
+	case CardMessageParagraph:
+		v.paragraph(path, &t)
+	default:
+		v.fail(path, "unsupported element %T", text)
+	}
+}
+
+func (v *cardValidator) sectionButton(path string, mode CardMessageSectionMode, b *CardMessageElementButton) {
+	if mode == CardMessageSectionModeLeft {
+		v.fail(path+".mode", "button could only be on the right")
+	}
+	v.button(path+".accessory", b)
+}
+
+func (v *cardValidator) paragraph(path string, p *CardMessageParagraph) {
+	if p.Cols < 1 || p.Cols > CardMaxParagraphCols {
+		v.fail(path+".cols", "cols should be 1 to %d, got %d", CardMaxParagraphCols, p.Cols)
+	}
+	if len(p.Fields) == 0 {
+		v.fail(path+".fields", "no fields")
+	}
+	if len(p.Fields) > CardMaxParagraphFields {
+		v.fail(path+".fields", "more than %d fields", CardMaxParagraphFields)
+	}
+	for i, item := range p.Fields {
+		fieldPath := fmt.Sprintf("%s.fields[%d]", path, i)
+		if isNilCardItem(item) {
+			v.fail(fieldPath, "nil field")
+			continue
+		}
+		switch f := item.(type) {
+		case *CardMessageElementText:
+			v.text(fieldPath, f, CardMaxPlainTextLength)
+		case CardMessageElementText:
+			v.text(fieldPath, &f, CardMaxPlainTextLength)
+		case *CardMessageElementKMarkdown:
+			v.kmarkdown(fieldPath, f)
+		case CardMessageElementKMarkdown:
+			v.kmarkdown(fieldPath, &f)
+		default:
+			v.fail(fieldPath, "unsupported element %T", item)
+		}
+	}
+}
+
+func (v *cardValidator) images(path string, images []CardMessageElementImage) {
+	if len(images) == 0 || len(images) > CardMaxImages {
+		v.fail(path+".elements", "should have 1 to %d images, got %d", CardMaxImages, len(images))
+	}
+	for i := range images {
+		v.image(fmt.Sprintf("%s.elements[%d]", path, i), &images[i])
+	}
+}
+
+func (v *cardValidator) buttons(path string, buttons []CardMessageElementButton) {
+	if len(buttons) == 0 || len(buttons) > CardMaxButtons {
+		v.fail(path+".elements", "should have 1 to %d buttons, got %d", CardMaxButtons, len(buttons))
+	}
+	for i := range buttons {
+		v.button(fmt.Sprintf("%s.elements[%d]", path, i), &buttons[i])
+	}
+}
+
+func (v *cardValidator) context(path string, elements []interface{}) {
+	if len(elements) == 0 || len(elements) > CardMaxContextElements {
+		v.fail(path+".elements", "should have 1 to %d elements, got %d", CardMaxContextElements, len(elements))
+	}
+	for i, item := range elements {
+		elementPath := fmt.Sprintf("%s.elements[%d]", path, i)
+		if isNilCardItem(item) {
+			v.fail(elementPath, "nil element")
+			continue
+		}
+		switch e := item.(type) {
+		case *CardMessageElementText:
+			v.text(elementPath, e, CardMaxPlainTextLength)
+		case CardMessageElementText:
+			v.text(elementPath, &e, CardMaxPlainTextLength)
+		case *CardMessageElementKMarkdown:
+			v.kmarkdown(elementPath, e)
+		case CardMessageElementKMarkdown:
+			v.kmarkdown(elementPath, &e)
+		case *CardMessageElementImage:
+			v.image(elementPath, e)
+		case CardMessageElementImage:
+			v.image(elementPath, &e)
+		default:
+			v.fail(elementPath, "unsupported element %T", item)
+		}
+	}
+}
+
+func (v *cardValidator) file(path string, f *CardMessageFile) {
+	switch f.Type {
+	case CardMessageFileTypeFile, CardMessageFileTypeAudio, CardMessageFileTypeVideo:
+	default:
+		v.fail(path+".type", "unknown file type %q", f.Type)
+	}
+	if f.Src == "" {
+		v.fail(path+".src", "empty src")
+	}
+}
+
+func (v *cardValidator) countdown(path string, c *CardMessageCountdown) {
+	switch c.Mode {
+	case CardMessageCountdownModeDay, CardMessageCountdownModeHour:
+	case CardMessageCountdownModeSecond:
+		if c.StartTime == 0 {
+			v.fail(path+".startTime", "startTime is required in second mode")
+		}
+	default:
+		v.fail(path+".mode", "unknown mode %q", c.Mode)
+	}
+	if c.EndTime == 0 {
+		v.fail(path+".endTime", "endTime is required")
+	} else if c.StartTime != 0 && c.EndTime <= c.StartTime {
+		v.fail(path+".endTime", "endTime should be after startTime")
+	}
+}
+
+func (v *cardValidator) text(path string, t *CardMessageElementText, max int) {
+	v.length(path+".content", t.Content, max)
+}
+
+func (v *cardValidator) kmarkdown(path string, k *CardMessageElementKMarkdown) {
+	v.length(path+".content", k.Content, CardMaxKMarkdownLength)
+}
+
+func (v *cardValidator) image(path string, i *CardMessageElementImage) {
+	if i.Src == "" {
+		v.fail(path+".src", "empty src")
+	}
+	if i.Size != "" && i.Size != string(CardSizeSm) && i.Size != string(CardSizeLg) {
+		v.fail(path+".size", "unknown size %q", i.Size)
+	}
+}
+
+func (v *cardValidator) button(path string, b *CardMessageElementButton) {
+	v.theme(path+".theme", b.Theme)
+	switch CardMessageElementButtonClick(b.Click) {
+	case "", CardMessageElementButtonClickReturnVal:
+	case CardMessageElementButtonClickLink:
+		if !strings.HasPrefix(b.Value, "http://") && !strings.HasPrefix(b.Value, "https://") {
+			v.fail(path+".value", "value of link button should be an http url")
+		}
+	default:
+		v.fail(path+".click", "unknown click %q", b.Click)
+	}
+	if b.Text == "" {
+		v.fail(path+".text", "empty text")
+	}
+}
+
+func (v *cardValidator) theme(path string, t CardTheme) {
+	switch t {
+	case "", CardThemePrimary, CardThemeSuccess, CardThemeDanger, CardThemeWarning, CardThemeInfo, CardThemeSecondary:
+	default:
+		v.fail(path, "unknown theme %q", t)
+	}
+}
+
+func (v *cardValidator) length(path, s string, max int) {
+	if n := utf8.RuneCountInString(s); n > max {
+		v.fail(path, "longer than %d characters, got %d", max, n)
+	}
+}
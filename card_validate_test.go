@@ -0,0 +1,69 @@
+package kook
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCardMessage_Validate(t *testing.T) {
+	valid := CardMessage{(&CardMessageCard{Theme: CardThemePrimary}).AddModule(
+		&CardMessageHeader{Text: CardMessageElementText{Content: "title"}},
+		(&CardMessageSection{Mode: CardMessageSectionModeRight}).
+			SetText(&CardMessageElementKMarkdown{Content: "hi"}).
+			SetAccessory(&CardMessageElementButton{Theme: CardThemePrimary, Value: "ok", Text: "OK"}),
+		&CardMessageCountdown{Mode: CardMessageCountdownModeDay, EndTime: 1000},
+	)}
+	if err := valid.Validate(); err != nil {
+		t.Error(err)
+	}
+	if _, err := valid.BuildMessage(CardBuildWithValidation()); err != nil {
+		t.Error(err)
+	}
+
+	invalid := CardMessage{(&CardMessageCard{}).AddModule(
+		&CardMessageImageGroup{},
+		&CardMessageActionGroup{{Text: "1"}, {Text: "2"}, {Text: "3"}, {Text: "4"}, {Text: "5"}},
+		(&CardMessageSection{}).SetText((&CardMessageParagraph{Cols: 4}).AddField(&CardMessageElementText{Content: "a"})),
+		&CardMessageCountdown{Mode: CardMessageCountdownModeSecond, EndTime: 1000},
+	)}
+	err := invalid.Validate()
+	errs, ok := err.(CardValidationErrors)
+	if !ok {
+		t.Fatal(err)
+	}
+	get := map[string]bool{}
+	for _, item := range errs {
+		get[item.Path] = true
+	}
+	for _, path := range []string{
+		"[0].modules[0].elements",
+		"[0].modules[1].elements",
+		"[0].modules[2].text.cols",
+		"[0].modules[3].startTime",
+	} {
+		if !get[path] {
+			t.Error(path, errs)
+		}
+	}
+	if !errors.Is(err, ErrInvalidCard) {
+		t.Error(err)
+	}
+	if _, err := invalid.BuildMessage(CardBuildWithValidation()); !errors.Is(err, ErrInvalidCard) {
+		t.Error(err)
+	}
+	if _, err := invalid.BuildMessage(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCardMessage_ValidateTypedNil(t *testing.T) {
+	c := CardMessage{{Modules: []interface{}{&CardMessageSection{Text: (*CardMessageElementText)(nil)}}}}
+	err := c.Validate()
+	errs, ok := err.(CardValidationErrors)
+	if !ok || len(errs) != 1 || errs[0].Path != "[0].modules[0].text" {
+		t.Error(err)
+	}
+	if _, err = c.BuildMessage(CardBuildWithValidation()); !errors.Is(err, ErrInvalidCard) {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,168 @@
+// Package cardtemplate renders card messages from templates, e.g. cards designed in the card editor of kook
+// with placeholders, using the semantics of text/template.
+//
+// Templates are JSON by default. As text/template knows nothing about JSON, values put into strings should be
+// escaped with the escape function, or marshaled with the json function:
+//
+//	[{"type": "card", "modules": [
+//	  {"type": "header", "text": {"type": "plain-text", "content": "Hello {{escape .Name}}"}}
+//	  {{- range .Items}},
+//	  {"type": "section", "text": {"type": "kmarkdown", "content": {{json .}}}}
+//	  {{- end}}
+//	]}]
+//
+// YAML templates are supported by the subpackage yamltemplate.
+package cardtemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/lonelyevil/kook"
+)
+
+// Decoder is the type for functions decoding rendered templates into card messages.
+type Decoder func([]byte) (kook.CardMessage, error)
+
+// Option is the type for optional arguments of Parse.
+type Option func(*config)
+
+type config struct {
+	funcs  template.FuncMap
+	decode Decoder
+}
+
+// WithFuncs adds functions to the template, see text/template.Template.Funcs.
+func WithFuncs(funcs template.FuncMap) Option {
+	return func(c *config) {
+		for k, v := range funcs {
+			c.funcs[k] = v
+		}
+	}
+}
+
+// WithDecoder sets the decoder of rendered templates, DecodeJSON by default.
+func WithDecoder(d Decoder) Option {
+	return func(c *config) {
+		c.decode = d
+	}
+}
+
+// Template is a parsed card message template.
+type Template struct {
+	name   string
+	t      *template.Template
+	decode Decoder
+}
+
+// Funcs returns the functions available in templates by default:
+//
+//	json    marshals the value into json, e.g. a quoted string
+//	escape  escapes the string to be put between quotes of a json string
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := marshal(v)
+			return string(b), err
+		},
+		"escape": func(s string) (string, error) {
+			b, err := marshal(s)
+			if err != nil {
+				return "", err
+			}
+			return string(b[1 : len(b)-1]), nil
+		},
+	}
+}
+
+// marshal is json.Marshal without escaping html, which is not needed in kmarkdown.
+func marshal(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Parse parses the template text.
+func Parse(name, text string, options ...Option) (*Template, error) {
+	c := &config{funcs: Funcs(), decode: DecodeJSON}
+	for _, item := range options {
+		item(c)
+	}
+	t, err := template.New(name).Option("missingkey=error").Funcs(c.funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("cardtemplate: %w", err)
+	}
+	return &Template{name: name, t: t, decode: c.decode}, nil
+}
+
+// ParseFile parses the template in the file, named by the base name of path.
+func ParseFile(path string, options ...Option) (*Template, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(filepath.Base(path), string(b), options...)
+}
+
+// Must panics if err is not nil, for templates parsed during initialization.
+func Must(t *Template, err error) *Template {
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Name returns the name of the template.
+func (t *Template) Name() string {
+	return t.name
+}
+
+// Execute renders the template with data into a card message, which is validated by CardMessage.Validate.
+func (t *Template) Execute(data interface{}) (kook.CardMessage, error) {
+	buf := &bytes.Buffer{}
+	if err := t.t.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("cardtemplate: %w", err)
+	}
+	c, err := t.decode(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("cardtemplate: %s: %w", t.name, err)
+	}
+	if err = c.Validate(); err != nil {
+		return c, fmt.Errorf("cardtemplate: %s: %w", t.name, err)
+	}
+	return c, nil
+}
+
+// ExecuteString renders the template into the content for sending, e.g. with kook.MessageCreateWithCard.
+func (t *Template) ExecuteString(data interface{}) (string, error) {
+	c, err := t.Execute(data)
+	if err != nil {
+		return "", err
+	}
+	return c.BuildMessage()
+}
+
+// DecodeJSON decodes a card message, which could also be a single card instead of a list.
+func DecodeJSON(b []byte) (kook.CardMessage, error) {
+	if strings.HasPrefix(strings.TrimSpace(string(b)), "{") {
+		card := &kook.CardMessageCard{}
+		if err := json.Unmarshal(b, card); err != nil {
+			return nil, err
+		}
+		return kook.CardMessage{card}, nil
+	}
+	var c kook.CardMessage
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
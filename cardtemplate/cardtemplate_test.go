@@ -0,0 +1,53 @@
+package cardtemplate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lonelyevil/kook"
+)
+
+const testTemplate = `[{"type": "card", "theme": "primary", "modules": [
+  {"type": "header", "text": {"type": "plain-text", "content": "Hello {{escape .Name}}"}}
+  {{- range .Items}},
+  {"type": "section", "text": {"type": "kmarkdown", "content": {{json .}}}}
+  {{- end}}
+  {{- if .Invite}},
+  {"type": "invite", "code": {{json .Invite}}}
+  {{- end}}
+]}]`
+
+func TestTemplate_Execute(t *testing.T) {
+	tpl := Must(Parse("test", testTemplate))
+	c, err := tpl.Execute(map[string]interface{}{
+		"Name":   `"kook"`,
+		"Items":  []string{"a", "<b>"},
+		"Invite": "",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c) != 1 || len(c[0].Modules) != 3 {
+		t.Fatal(c)
+	}
+	if get := c[0].Modules[0].(*kook.CardMessageHeader).Text.Content; get != `Hello "kook"` {
+		t.Error(get)
+	}
+	if get := c[0].Modules[2].(*kook.CardMessageSection).Text.(*kook.CardMessageElementKMarkdown).Content; get != "<b>" {
+		t.Error(get)
+	}
+	if _, err = tpl.ExecuteString(map[string]interface{}{"Name": "", "Items": nil, "Invite": "abc"}); err != nil {
+		t.Error(err)
+	}
+	_, err = tpl.Execute(map[string]interface{}{"Name": "", "Items": []string{}, "Invite": ""})
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = Must(Parse("invalid", `{"type": "card", "modules": [{"type": "action-group", "elements": []}]}`)).Execute(nil)
+	if !errors.Is(err, kook.ErrInvalidCard) {
+		t.Error(err)
+	}
+	if _, err = tpl.Execute(map[string]interface{}{}); err == nil {
+		t.Error("missing key")
+	}
+}
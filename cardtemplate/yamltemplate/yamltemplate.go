@@ -0,0 +1,83 @@
+// Package yamltemplate renders card messages from YAML templates, see package cardtemplate.
+//
+// A YAML template mirrors the JSON of card messages, and could be a single card or a list of cards:
+//
+//	type: card
+//	theme: primary
+//	modules:
+//	  - type: header
+//	    text: {type: plain-text, content: {{json .Title}}}
+//	{{- range .Items}}
+//	  - type: section
+//	    text: {type: kmarkdown, content: {{json .}}}
+//	{{- end}}
+//
+// Values should be marshaled with the json function, as a json string is a valid YAML string.
+package yamltemplate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonelyevil/kook"
+	"github.com/lonelyevil/kook/cardtemplate"
+	"gopkg.in/yaml.v2"
+)
+
+// Parse parses the YAML template text.
+func Parse(name, text string, options ...cardtemplate.Option) (*cardtemplate.Template, error) {
+	return cardtemplate.Parse(name, text, append(options, cardtemplate.WithDecoder(Decode))...)
+}
+
+// ParseFile parses the YAML template in the file.
+func ParseFile(path string, options ...cardtemplate.Option) (*cardtemplate.Template, error) {
+	return cardtemplate.ParseFile(path, append(options, cardtemplate.WithDecoder(Decode))...)
+}
+
+// Decode decodes a card message in YAML, which could also be a single card instead of a list.
+func Decode(b []byte) (kook.CardMessage, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	v, err := convert(v)
+	if err != nil {
+		return nil, err
+	}
+	j, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return cardtemplate.DecodeJSON(j)
+}
+
+// convert converts maps decoded by yaml into ones could be marshaled into json.
+func convert(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, item := range t {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("yamltemplate: non-string key %v", k)
+			}
+			value, err := convert(item)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = value
+		}
+		return m, nil
+	case []interface{}:
+		for i, item := range t {
+			value, err := convert(item)
+			if err != nil {
+				return nil, err
+			}
+			t[i] = value
+		}
+		return t, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,46 @@
+package yamltemplate
+
+import (
+	"testing"
+
+	"github.com/lonelyevil/kook"
+)
+
+const testTemplate = `type: card
+theme: primary
+modules:
+  - type: header
+    text: {type: plain-text, content: {{json .Title}}}
+{{- range .Items}}
+  - type: section
+    text: {type: kmarkdown, content: {{json .}}}
+    accessory: {type: button, theme: danger, value: v, text: {type: plain-text, content: Go}}
+{{- end}}
+`
+
+func TestParse(t *testing.T) {
+	tpl, err := Parse("test", testTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := tpl.Execute(map[string]interface{}{
+		"Title": "title: with colon",
+		"Items": []string{"a", "- b"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c) != 1 || c[0].Theme != kook.CardThemePrimary || len(c[0].Modules) != 3 {
+		t.Fatal(c)
+	}
+	if get := c[0].Modules[0].(*kook.CardMessageHeader).Text.Content; get != "title: with colon" {
+		t.Error(get)
+	}
+	s := c[0].Modules[2].(*kook.CardMessageSection)
+	if get := s.Text.(*kook.CardMessageElementKMarkdown).Content; get != "- b" {
+		t.Error(get)
+	}
+	if get := s.Accessory.(*kook.CardMessageElementButton).Text; get != "Go" {
+		t.Error(get)
+	}
+}
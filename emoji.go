@@ -0,0 +1,111 @@
+package kook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lonelyevil/kook/emoji"
+)
+
+// ErrEmojiNotFound is the error when a guild emoji could not be found by its name.
+var ErrEmojiNotFound = errors.New("guild emoji not found")
+
+// reactionEmoji converts emoji in any representation, e.g. "[#128077;]" or "(emj)kook(emj)[id]",
+// into the one accepted by reaction endpoints.
+func reactionEmoji(e string) string {
+	return emoji.Normalize(e)
+}
+
+// GuildEmojiCache resolves guild emoji by their names, caching GuildEmojiList of guilds.
+type GuildEmojiCache struct {
+	Session *Session
+	// TTL is how long emoji of a guild are cached, forever if it is zero.
+	TTL time.Duration
+
+	mu     sync.Mutex
+	guilds map[string]*guildEmojis
+}
+
+// guildEmojis is the cache of a guild, locked while its emoji are requested, so that other guilds are not blocked.
+type guildEmojis struct {
+	mu    sync.Mutex
+	at    time.Time
+	items []*GuildEmojiResp
+}
+
+// NewGuildEmojiCache creates a GuildEmojiCache.
+func NewGuildEmojiCache(s *Session, ttl time.Duration) *GuildEmojiCache {
+	return &GuildEmojiCache{Session: s, TTL: ttl, guilds: map[string]*guildEmojis{}}
+}
+
+// List returns the emoji of the guild, which is requested if it is not cached or expired.
+func (c *GuildEmojiCache) List(ctx context.Context, guildID string) ([]*GuildEmojiResp, error) {
+	c.mu.Lock()
+	if c.guilds == nil {
+		c.guilds = map[string]*guildEmojis{}
+	}
+	g, ok := c.guilds[guildID]
+	if !ok {
+		g = &guildEmojis{}
+		c.guilds[guildID] = g
+	}
+	c.mu.Unlock()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.at.IsZero() && (c.TTL == 0 || time.Since(g.at) < c.TTL) {
+		return g.items, nil
+	}
+	items, err := c.Session.GuildEmojiListAll(ctx, guildID, nil).Collect()
+	if err != nil {
+		return nil, err
+	}
+	g.at, g.items = time.Now(), items
+	return items, nil
+}
+
+// Invalidate drops the cached emoji of the guild, e.g. when handling EmojiUpdatedContext.
+func (c *GuildEmojiCache) Invalidate(guildID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.guilds, guildID)
+}
+
+// Resolve parses emoji in any representation of kook. Names of guild emoji, with or without colons,
+// are looked up in the guild, and ErrEmojiNotFound is returned if none matches.
+// Guild emoji found have their names filled.
+func (c *GuildEmojiCache) Resolve(ctx context.Context, guildID, s string) (emoji.Emoji, error) {
+	e, err := emoji.Parse(s)
+	if err == nil && e.Kind == emoji.KindUnicode {
+		return e, nil
+	}
+	if err == nil && e.Kind == emoji.KindGuild && e.Name != "" {
+		return e, nil
+	}
+	items, listErr := c.List(ctx, guildID)
+	if listErr != nil {
+		return emoji.Emoji{}, listErr
+	}
+	name := strings.Trim(s, ":")
+	if err == nil && e.Kind == emoji.KindShortCode {
+		name = e.Name
+	}
+	for _, item := range items {
+		if err == nil && e.Kind == emoji.KindGuild {
+			if item.ID == e.ID {
+				return emoji.Guild(item.ID, item.Name), nil
+			}
+			continue
+		}
+		if item.Name == name {
+			return emoji.Guild(item.ID, item.Name), nil
+		}
+	}
+	if err == nil && e.Kind == emoji.KindGuild {
+		return e, nil
+	}
+	return emoji.Emoji{}, ErrEmojiNotFound
+}
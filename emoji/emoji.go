@@ -0,0 +1,226 @@
+// Package emoji parses and formats the representations of emoji in kook:
+//
+//	👍                    unicode emoji, which could be a sequence of code points like 👨‍👩‍👧
+//	[#128077;]            unicode emoji as code points, e.g. EmojiItem.ID in reaction events
+//	1234567890/abcdefg    id of a custom emoji of a guild
+//	(emj)kook(emj)[id]    custom emoji of a guild in KMarkdown
+//	:smile:               short code in KMarkdown
+package emoji
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalid is the error when a string is not an emoji.
+var ErrInvalid = errors.New("emoji: invalid emoji")
+
+// Kind is the type for kinds of Emoji.
+type Kind int
+
+// These are kinds of emoji.
+const (
+	// KindUnicode is a unicode emoji.
+	KindUnicode Kind = iota + 1
+	// KindGuild is a custom emoji of a guild.
+	KindGuild
+	// KindShortCode is an emoji by its short code, which could only be used in KMarkdown.
+	KindShortCode
+)
+
+// Emoji is an emoji in kook.
+type Emoji struct {
+	Kind Kind
+	// Unicode is the emoji of KindUnicode.
+	Unicode string
+	// ID is the id of KindGuild, like "1234567890/abcdefg".
+	ID string
+	// Name is the name of KindGuild or the short code of KindShortCode, without colons.
+	Name string
+}
+
+// Unicode creates a unicode emoji.
+func Unicode(s string) Emoji {
+	return Emoji{Kind: KindUnicode, Unicode: s}
+}
+
+// Guild creates a custom emoji of a guild, the name is only used in KMarkdown.
+func Guild(id, name string) Emoji {
+	return Emoji{Kind: KindGuild, ID: id, Name: name}
+}
+
+// ShortCode creates an emoji by its short code, e.g. "smile".
+func ShortCode(name string) Emoji {
+	return Emoji{Kind: KindShortCode, Name: name}
+}
+
+// Parse parses any representation of emoji in kook.
+func Parse(s string) (Emoji, error) {
+	switch {
+	case s == "":
+		return Emoji{}, ErrInvalid
+	case strings.HasPrefix(s, "[#"):
+		u, err := DecodeCodePoints(s)
+		if err != nil {
+			return Emoji{}, err
+		}
+		return Unicode(u), nil
+	case strings.HasPrefix(s, "(emj)"):
+		rest := s[5:]
+		end := strings.Index(rest, "(emj)")
+		if end < 0 || !strings.HasPrefix(rest[end+5:], "[") || !strings.HasSuffix(s, "]") {
+			return Emoji{}, ErrInvalid
+		}
+		id := rest[end+6 : len(rest)-1]
+		if !isGuildID(id) {
+			return Emoji{}, ErrInvalid
+		}
+		return Guild(id, rest[:end]), nil
+	case len(s) > 2 && s[0] == ':' && s[len(s)-1] == ':':
+		name := s[1 : len(s)-1]
+		if strings.ContainsAny(name, ": \t\n") {
+			return Emoji{}, ErrInvalid
+		}
+		return ShortCode(name), nil
+	case isGuildID(s):
+		return Guild(s, ""), nil
+	}
+	if n := match(s); n == len(s) {
+		return Unicode(s), nil
+	}
+	return Emoji{}, ErrInvalid
+}
+
+// isGuildID reports whether s is like "1234567890/abcdefg".
+func isGuildID(s string) bool {
+	i := strings.IndexByte(s, '/')
+	if i <= 0 || i == len(s)-1 {
+		return false
+	}
+	for _, c := range s[:i] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	for _, c := range s[i+1:] {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// Reaction returns the emoji used to add reactions, which is the unicode emoji or the id of a guild emoji.
+// Short codes could not be used in reactions, and are returned as is.
+func (e Emoji) Reaction() string {
+	switch e.Kind {
+	case KindUnicode:
+		return e.Unicode
+	case KindGuild:
+		return e.ID
+	default:
+		return ":" + e.Name + ":"
+	}
+}
+
+// KMarkdown returns the emoji in KMarkdown.
+func (e Emoji) KMarkdown() string {
+	switch e.Kind {
+	case KindUnicode:
+		return e.Unicode
+	case KindGuild:
+		name := e.Name
+		if name == "" {
+			name = e.ID
+		}
+		return "(emj)" + name + "(emj)[" + e.ID + "]"
+	default:
+		return ":" + e.Name + ":"
+	}
+}
+
+// String implements fmt.Stringer, returning the emoji in KMarkdown.
+func (e Emoji) String() string {
+	return e.KMarkdown()
+}
+
+// Equal reports whether the emoji are the same, ignoring names of guild emoji.
+func (e Emoji) Equal(o Emoji) bool {
+	if e.Kind != o.Kind {
+		return false
+	}
+	switch e.Kind {
+	case KindUnicode:
+		return strings.TrimSuffix(e.Unicode, "\ufe0f") == strings.TrimSuffix(o.Unicode, "\ufe0f")
+	case KindGuild:
+		return e.ID == o.ID
+	default:
+		return e.Name == o.Name
+	}
+}
+
+// Equal reports whether the representations are of the same emoji. Strings not parsed are compared as is.
+func Equal(a, b string) bool {
+	x, errA := Parse(a)
+	y, errB := Parse(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return x.Equal(y)
+}
+
+// Normalize converts the representation into the one used to add reactions, see Emoji.Reaction.
+// Strings not parsed are returned as is.
+func Normalize(s string) string {
+	e, err := Parse(s)
+	if err != nil {
+		return s
+	}
+	return e.Reaction()
+}
+
+// EncodeCodePoints encodes the string like "[#128077;]", with all code points in the brackets.
+func EncodeCodePoints(s string) string {
+	b := &strings.Builder{}
+	b.WriteString("[")
+	for _, r := range s {
+		b.WriteString("#" + strconv.Itoa(int(r)) + ";")
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// DecodeCodePoints decodes strings like "[#128077;]". Code points could be in one or more brackets,
+// like "[#128104;#8205;#128105;]" or "[#128104;][#8205;][#128105;]".
+func DecodeCodePoints(s string) (string, error) {
+	b := &strings.Builder{}
+	for s != "" {
+		if !strings.HasPrefix(s, "[") {
+			return "", ErrInvalid
+		}
+		end := strings.IndexByte(s, ']')
+		if end < 0 {
+			return "", ErrInvalid
+		}
+		group := s[1:end]
+		s = s[end+1:]
+		if group == "" {
+			return "", ErrInvalid
+		}
+		for group != "" {
+			semi := strings.IndexByte(group, ';')
+			if !strings.HasPrefix(group, "#") || semi < 0 {
+				return "", ErrInvalid
+			}
+			i, err := strconv.ParseInt(group[1:semi], 10, 32)
+			if err != nil || !utf8.ValidRune(rune(i)) {
+				return "", ErrInvalid
+			}
+			b.WriteRune(rune(i))
+			group = group[semi+1:]
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,65 @@
+package emoji
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := map[string]Emoji{
+		"👍":                                  Unicode("👍"),
+		"👍🏽":                                 Unicode("👍🏽"),
+		"👨\u200d👩\u200d👧":                    Unicode("👨\u200d👩\u200d👧"),
+		"🇨🇳":                                 Unicode("🇨🇳"),
+		"1\ufe0f\u20e3":                      Unicode("1\ufe0f\u20e3"),
+		"[#128077;]":                         Unicode("👍"),
+		"[#128104;#8205;#128105;]":           Unicode("👨\u200d👩"),
+		"[#128104;][#8205;][#128105;]":       Unicode("👨\u200d👩"),
+		"1234567890/abcdefg":                 Guild("1234567890/abcdefg", ""),
+		"(emj)kook(emj)[1234567890/abcdefg]": Guild("1234567890/abcdefg", "kook"),
+		":smile:":                            ShortCode("smile"),
+	}
+	for s, expected := range cases {
+		get, err := Parse(s)
+		if err != nil || get != expected {
+			t.Error(s, get, err)
+		}
+	}
+	for _, s := range []string{"", "a", "👍a", "[#abc;]", "[#128077;", "(emj)kook(emj)", "12/", ":a b:"} {
+		if get, err := Parse(s); err != ErrInvalid {
+			t.Error(s, get, err)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	e := Guild("1234567890/abcdefg", "kook")
+	if get := e.KMarkdown(); get != "(emj)kook(emj)[1234567890/abcdefg]" {
+		t.Error(get)
+	}
+	if get := e.Reaction(); get != "1234567890/abcdefg" {
+		t.Error(get)
+	}
+	if get := EncodeCodePoints("👍🏽"); get != "[#128077;#127997;]" {
+		t.Error(get)
+	}
+	if get := Normalize("[#128077;]"); get != "👍" {
+		t.Error(get)
+	}
+	if !Equal("[#10084;]", "❤\ufe0f") || Equal("👍", "👎") {
+		t.Error("equal")
+	}
+}
+
+func TestFind(t *testing.T) {
+	get := Find("hi 👋🏻 family 👨\u200d👩\u200d👧 flag 🇯🇵 #\ufe0f\u20e3 end")
+	expected := []string{"👋🏻", "👨\u200d👩\u200d👧", "🇯🇵", "#\ufe0f\u20e3"}
+	if len(get) != len(expected) {
+		t.Fatal(get)
+	}
+	for i := range get {
+		if get[i] != expected[i] {
+			t.Error(i, get[i])
+		}
+	}
+	if !IsEmoji("👍🏽") || IsEmoji("👍👍") {
+		t.Error("is emoji")
+	}
+}
@@ -0,0 +1,102 @@
+package emoji
+
+import "unicode/utf8"
+
+const (
+	zwj           = '\u200d'
+	variation16   = '\ufe0f'
+	variation15   = '\ufe0e'
+	keycap        = '\u20e3'
+	regionalFirst = 0x1F1E6
+	regionalLast  = 0x1F1FF
+	skinToneFirst = 0x1F3FB
+	skinToneLast  = 0x1F3FF
+	tagFirst      = 0xE0020
+	tagLast       = 0xE007F
+)
+
+// isPictographic reports whether the rune starts an emoji. The ranges cover emoji in Unicode 15,
+// with some symbols not usually displayed as emoji.
+func isPictographic(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF,
+		r >= 0x2600 && r <= 0x27BF,
+		r >= 0x2300 && r <= 0x23FF,
+		r >= 0x2B05 && r <= 0x2B55,
+		r >= 0x2190 && r <= 0x21FF,
+		r >= 0x25A0 && r <= 0x25FF,
+		r == 0x00A9, r == 0x00AE, r == 0x203C, r == 0x2049, r == 0x2122, r == 0x2139,
+		r == 0x24C2, r == 0x2934, r == 0x2935, r == 0x3030, r == 0x303D, r == 0x3297, r == 0x3299:
+		return true
+	}
+	return false
+}
+
+func isKeycapBase(r rune) bool {
+	return r >= '0' && r <= '9' || r == '#' || r == '*'
+}
+
+func isRegional(r rune) bool {
+	return r >= regionalFirst && r <= regionalLast
+}
+
+// match returns the length in bytes of the emoji at the start of s, or 0. Sequences like flags, keycaps,
+// skin tones and ZWJ sequences are matched as a whole.
+func match(s string) int {
+	r, n := utf8.DecodeRuneInString(s)
+	switch {
+	case isRegional(r):
+		if r2, n2 := utf8.DecodeRuneInString(s[n:]); isRegional(r2) {
+			return n + n2
+		}
+		return n
+	case isKeycapBase(r):
+		i := n
+		if r2, n2 := utf8.DecodeRuneInString(s[i:]); r2 == variation16 {
+			i += n2
+		}
+		if r2, n2 := utf8.DecodeRuneInString(s[i:]); r2 == keycap {
+			return i + n2
+		}
+		return 0
+	case !isPictographic(r):
+		return 0
+	}
+	i := n
+	for i < len(s) {
+		r, n = utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == variation16, r == variation15, r >= skinToneFirst && r <= skinToneLast, r >= tagFirst && r <= tagLast:
+			i += n
+		case r == zwj:
+			next, n2 := utf8.DecodeRuneInString(s[i+n:])
+			if !isPictographic(next) {
+				return i
+			}
+			i += n + n2
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// Find returns the unicode emoji in s, in the order they appear.
+func Find(s string) []string {
+	var found []string
+	for i := 0; i < len(s); {
+		if n := match(s[i:]); n > 0 {
+			found = append(found, s[i:i+n])
+			i += n
+			continue
+		}
+		_, n := utf8.DecodeRuneInString(s[i:])
+		i += n
+	}
+	return found
+}
+
+// IsEmoji reports whether s is exactly one unicode emoji, which could be a sequence of code points.
+func IsEmoji(s string) bool {
+	return s != "" && match(s) == len(s)
+}
@@ -0,0 +1,102 @@
+package kook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lonelyevil/kook/emoji"
+)
+
+func TestGuildEmojiCache_Resolve(t *testing.T) {
+	requests := 0
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/guild-emoji/list":
+			requests++
+			w.Write([]byte(`{"code":0,"message":"","data":{"items":[{"name":"kook","id":"1/abc"}],` +
+				`"meta":{"page":1,"page_total":1,"page_size":50,"total":1}}}`))
+		default:
+			body = nil
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(`{"code":0,"message":"","data":[]}`))
+		}
+	}))
+	defer ts.Close()
+	s := New("", mockLogger{}, SessionWithAPIBase(ts.URL))
+	c := NewGuildEmojiCache(s, 0)
+	for _, name := range []string{"kook", ":kook:", "1/abc"} {
+		e, err := c.Resolve(context.Background(), "1", name)
+		if err != nil || e != emoji.Guild("1/abc", "kook") {
+			t.Error(name, e, err)
+		}
+	}
+	if e, err := c.Resolve(context.Background(), "1", "[#128077;]"); err != nil || e.Unicode != "👍" {
+		t.Error(e, err)
+	}
+	for _, name := range []string{"missing", ":missing:"} {
+		if e, err := c.Resolve(context.Background(), "1", name); err != ErrEmojiNotFound {
+			t.Error(name, e, err)
+		}
+	}
+	if requests != 1 {
+		t.Error(requests)
+	}
+
+	if err := s.MessageAddReaction("m1", "(emj)kook(emj)[1/abc]"); err != nil || body["emoji"] != "1/abc" {
+		t.Error(body, err)
+	}
+	if err := s.DirectMessageAddReaction("m1", "[#128077;]"); err != nil || body["emoji"] != "👍" {
+		t.Error(body, err)
+	}
+}
+
+func TestEmojiItem_Convert(t *testing.T) {
+	e := &EmojiItem{ID: "[#128077;#127997;]", Name: ":+1:"}
+	if get := e.Convert(); get != "👍🏽" {
+		t.Error(get)
+	}
+	if !e.IsEqual("👍🏽") {
+		t.Error(e)
+	}
+	e = &EmojiItem{ID: "[#bad;]"}
+	if get := e.Convert(); get != "[#bad;]" {
+		t.Error(get)
+	}
+	e = &EmojiItem{ID: "1/abc", Name: "kook"}
+	if get, err := e.Emoji(); err != nil || get.KMarkdown() != "(emj)kook(emj)[1/abc]" {
+		t.Error(get, err)
+	}
+}
+
+func TestGuildEmojiCache_ListConcurrent(t *testing.T) {
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("guild_id") == "slow" {
+			<-release
+		}
+		w.Write([]byte(`{"code":0,"message":"","data":{"items":[],"meta":{"page":1,"page_total":1,"page_size":50,"total":0}}}`))
+	}))
+	defer ts.Close()
+	defer close(release)
+	c := NewGuildEmojiCache(New("", mockLogger{}, SessionWithAPIBase(ts.URL)), 0)
+	go c.List(context.Background(), "slow")
+	done := make(chan error)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_, err := c.List(context.Background(), "fast")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("blocked by the request of another guild")
+	}
+}
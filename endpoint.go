@@ -3,6 +3,7 @@ package kook
 import (
 	"net/url"
 	"path"
+	"strings"
 )
 
 // All endpoints for http request
@@ -121,6 +122,16 @@ var (
 	EndpointGameDeleteActivity = urlJoin(EndpointGame, "delete-activity")
 )
 
+// endpoint resolves a package level endpoint against the api base of the session.
+//
+// Endpoints outside EndpointAPI are returned untouched.
+func (s *Session) endpoint(e string) string {
+	if s.endpointAPI == "" || s.endpointAPI == EndpointAPI || !strings.HasPrefix(e, EndpointAPI) {
+		return e
+	}
+	return s.endpointAPI + strings.TrimPrefix(e, EndpointAPI)
+}
+
 // Must not be used elsewhere.
 func urlJoin(u1, u2 string) string {
 	r, err := url.Parse(u1)
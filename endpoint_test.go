@@ -0,0 +1,20 @@
+package kook
+
+import "testing"
+
+func TestSession_endpoint(t *testing.T) {
+	s := New("", nil, SessionWithAPIBase("http://127.0.0.1:8080/api"))
+	get := s.endpoint(EndpointMessageCreate)
+	if get != "http://127.0.0.1:8080/api/v3/message/create" {
+		t.Error(get)
+	}
+	get = s.endpoint("https://example.com/other")
+	if get != "https://example.com/other" {
+		t.Error(get)
+	}
+	s = New("", nil)
+	get = s.endpoint(EndpointMessageCreate)
+	if get != EndpointMessageCreate {
+		t.Error(get)
+	}
+}
@@ -2,14 +2,63 @@ package kook
 
 import (
 	"encoding/json"
+	"errors"
+	"net/http"
 	"strconv"
 )
 
+// These are the known codes of RestError. Kook composes a code from the http status followed by two digits,
+// so a code like 40012 belongs to RestCodeBadRequest, see RestCodeStatus.
+//
+// FYI: https://developer.kookapp.cn/doc/http-api
+const (
+	RestCodeOK                 = 0
+	RestCodeBadRequest         = 40000
+	RestCodeUnauthorized       = 40100
+	RestCodePermissionDenied   = 40300
+	RestCodeNotFound           = 40400
+	RestCodeMethodNotAllowed   = 40500
+	RestCodeConflict           = 40900
+	RestCodePayloadTooLarge    = 41300
+	RestCodeRateLimited        = 42900
+	RestCodeServerError        = 50000
+	RestCodeBadGateway         = 50200
+	RestCodeServiceUnavailable = 50300
+	RestCodeGatewayTimeout     = 50400
+)
+
+// RestCodeStatus returns the http status a code of RestError is composed from, or 0 if the code is not composed so.
+func RestCodeStatus(code int) int {
+	status := code / 100
+	if status < 100 || status > 599 {
+		return 0
+	}
+	return status
+}
+
+// These are the sentinel errors which a RestError could be tested against with errors.Is.
+var (
+	ErrBadRequest       = errors.New("bad request")
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrNotFound         = errors.New("not found")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+	ErrConflict         = errors.New("conflict")
+	ErrPayloadTooLarge  = errors.New("payload too large")
+	ErrRateLimited      = errors.New("rate limited")
+	ErrServerError      = errors.New("server error")
+	ErrUnavailable      = errors.New("service unavailable")
+)
+
 // RestError is the error type for errors from kook
 type RestError struct {
 	Code    int             `json:"code"`
 	Message string          `json:"message"`
 	Data    json.RawMessage `json:"data"`
+	// HTTPStatus is the status code of the http response.
+	HTTPStatus int `json:"-"`
+	// RateLimit is the rate limit status of the response, if reported.
+	RateLimit *RateLimitInfo `json:"-"`
 }
 
 // Error provides the formatted error string
@@ -17,6 +66,61 @@ func (r RestError) Error() string {
 	return "[" + strconv.Itoa(r.Code) + "] " + r.Message
 }
 
-func newRestErrorFromGeneralResp(r *EndpointGeneralResponse) error {
-	return &RestError{Code: r.Code, Message: r.Message, Data: r.Data}
+// Is matches the sentinel errors by the code, or the http status if the code is unknown.
+func (r RestError) Is(target error) bool {
+	status := RestCodeStatus(r.Code)
+	if status == 0 {
+		status = r.HTTPStatus
+	}
+	switch target {
+	case ErrBadRequest:
+		return status == http.StatusBadRequest
+	case ErrUnauthorized:
+		return status == http.StatusUnauthorized
+	case ErrPermissionDenied:
+		return status == http.StatusForbidden
+	case ErrNotFound:
+		return status == http.StatusNotFound
+	case ErrMethodNotAllowed:
+		return status == http.StatusMethodNotAllowed
+	case ErrConflict:
+		return status == http.StatusConflict
+	case ErrPayloadTooLarge:
+		return status == http.StatusRequestEntityTooLarge || r.HTTPStatus == http.StatusRequestEntityTooLarge
+	case ErrRateLimited:
+		return status == http.StatusTooManyRequests || r.HTTPStatus == http.StatusTooManyRequests
+	case ErrServerError:
+		return status >= 500 && status <= 599
+	case ErrUnavailable:
+		return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+	}
+	return false
+}
+
+func newRestErrorFromGeneralResp(r *EndpointGeneralResponse, resp *http.Response) error {
+	return &RestError{
+		Code:       r.Code,
+		Message:    r.Message,
+		Data:       r.Data,
+		HTTPStatus: resp.StatusCode,
+		RateLimit:  parseRateLimitInfo(resp.Header),
+	}
+}
+
+// RequestError is the error when a http api request fails before kook answers with a result,
+// e.g. a network error, or a response which could not be read or parsed.
+type RequestError struct {
+	Method string
+	URL    string
+	Err    error
+}
+
+// Error provides the formatted error string
+func (r *RequestError) Error() string {
+	return r.Method + " " + r.URL + ": " + r.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (r *RequestError) Unwrap() error {
+	return r.Err
 }
@@ -0,0 +1,62 @@
+package kook
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRestError_Is(t *testing.T) {
+	var err error = &RestError{Code: RestCodeNotFound, Message: "not found", HTTPStatus: 200}
+	if !errors.Is(fmt.Errorf("wrapped: %w", err), ErrNotFound) {
+		t.Error(err)
+	}
+	if errors.Is(err, ErrPermissionDenied) {
+		t.Error(err)
+	}
+	err = &RestError{Message: "429 Too Many Requests", HTTPStatus: 429}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Error(err)
+	}
+	err = &RestError{Code: 50001, HTTPStatus: 500}
+	if !errors.Is(err, ErrServerError) || errors.Is(err, ErrUnavailable) {
+		t.Error(err)
+	}
+	err = &RestError{Code: RestCodeServiceUnavailable + 3, HTTPStatus: 503}
+	if !errors.Is(err, ErrServerError) || !errors.Is(err, ErrUnavailable) {
+		t.Error(err)
+	}
+	err = &RestError{Message: "413 Request Entity Too Large", HTTPStatus: 413}
+	if !errors.Is(err, ErrPayloadTooLarge) {
+		t.Error(err)
+	}
+	if get := RestCodeStatus(40012); get != 400 {
+		t.Error(get)
+	}
+	if get := RestCodeStatus(123); get != 0 {
+		t.Error(get)
+	}
+}
+
+func TestSession_RequestError(t *testing.T) {
+	s := New("", mockLogger{})
+	_, err := s.Request("POST", EndpointMessageCreate, func() {})
+	var re *RequestError
+	if !errors.As(err, &re) || re.Method != "POST" {
+		t.Error(err)
+	}
+	_, err = s.Request("GET\n", EndpointUserMe, nil)
+	if !errors.As(err, &re) {
+		t.Error(err)
+	}
+}
+
+func TestRequestError(t *testing.T) {
+	var err error = &RequestError{Method: "GET", URL: EndpointGateway, Err: ErrReadLimit}
+	if !errors.Is(err, ErrReadLimit) {
+		t.Error(err)
+	}
+	if get := err.Error(); get != "GET "+EndpointGateway+": read limit exceeded" {
+		t.Error(get)
+	}
+}
@@ -75,15 +75,28 @@ func (s *Session) removeEventHandler(t string, ehi *eventHandlerInstance) {
 
 func (s *Session) handle(t string, i EventContext) {
 	for _, eh := range s.handlers[t] {
+		if !s.inflight.add(true) {
+			addCaller(s.Logger.Debug()).Str("type", t).Msg("session is shut down, event dropped")
+			return
+		}
 		if s.Sync {
-			eh.eventHandler.Handle(i)
+			s.runHandler(eh, i)
 		} else {
-			go eh.eventHandler.Handle(i)
+			go s.runHandler(eh, i)
 		}
 	}
 }
 
+func (s *Session) runHandler(eh *eventHandlerInstance, i EventContext) {
+	defer s.inflight.done(true)
+	eh.eventHandler.Handle(i)
+}
+
 func (s *Session) handleEvent(t string, edg *EventDataGeneral, i EventContext) {
+	if s.isShutdown() {
+		addCaller(s.Logger.Debug()).Str("type", t).Msg("session is shut down, event dropped")
+		return
+	}
 	s.handlersMu.RLock()
 	defer s.handlersMu.RUnlock()
 	c := i.GetCommon()
@@ -2,46 +2,50 @@ package kook
 
 // Reply is a helper function for replying to a message.
 //
-// It accept optional MessageCreateOption, DirectMessageCreateOption and ReplyOption arguments.
-func (c *TextMessageContext) Reply(text string, options ...interface{}) (*MessageResp, error) {
-	if c.Common.ChannelType == "PERSON" {
-		dmc := &DirectMessageCreate{
-			ChatCode: c.Common.TargetID,
-			MessageCreateBase: MessageCreateBase{
-				Quote:   c.Common.MsgID,
-				Content: text,
-			},
-		}
-		for _, item := range options {
-			switch v := item.(type) {
-			case DirectMessageCreateOption:
-				v(dmc)
-			default:
-			}
-		}
-		return c.Session.DirectMessageCreate(dmc)
-	}
-	mc := &MessageCreate{
-		MessageCreateBase: MessageCreateBase{
-			Quote:    c.Common.MsgID,
-			Content:  text,
-			TargetID: c.Common.TargetID,
-		},
-	}
+// It accept optional SendOption, MessageCreateOption, DirectMessageCreateOption and ReplyOption arguments.
+// MessageCreateOption and ReplyOption only apply to messages in channels, while DirectMessageCreateOption
+// only applies to direct messages.
+func (ctx *TextMessageContext) Reply(text string, options ...interface{}) (*MessageResp, error) {
+	m := ctx.Message()
+	var sendOptions []SendOption
+	mc := &MessageCreate{}
+	dmc := &DirectMessageCreate{}
 	for _, item := range options {
 		switch v := item.(type) {
+		case SendOption:
+			sendOptions = append(sendOptions, v)
 		case MessageCreateOption:
 			v(mc)
+		case DirectMessageCreateOption:
+			v(dmc)
 		case ReplyOption:
 			switch v {
 			case ReplyOptionTemp:
-				mc.TempTargetID = c.Extra.Author.ID
+				mc.TempTargetID = ctx.Common.AuthorID
 			}
 		default:
 		}
 	}
-	return c.Session.MessageCreate(mc)
-
+	base := mc.MessageCreateBase
+	if m.Target.Direct() {
+		base = dmc.MessageCreateBase
+	} else if mc.TempTargetID != "" {
+		sendOptions = append(sendOptions, SendWithTempTarget(mc.TempTargetID))
+	}
+	if base.Type != 0 {
+		sendOptions = append(sendOptions, SendWithType(base.Type))
+	}
+	if base.Nonce != "" {
+		sendOptions = append(sendOptions, SendWithNonce(base.Nonce))
+	}
+	if base.Quote != "" {
+		sendOptions = append(sendOptions, SendWithQuote(base.Quote))
+	}
+	sent, err := m.reply(text, sendOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageResp{MsgID: sent.ID, MsgTimestamp: sent.Timestamp, Nonce: sent.Nonce}, nil
 }
 
 // MessageCreateOption is the type for decorator of MessageCreate.
@@ -6,324 +6,310 @@ Don't edit it.
 */
 
 //revive:disable
-type EventReactionItem struct {
-	Emoji     EventEmojiItem `json:"emoji"`
-	MsgID     string         `json:"msg_id"`
-	UserID    string         `json:"user_id"`
-	ChannelID string         `json:"channel_id"`
-}
-type EventDirectChatReactionItem struct {
-	MsgID    string         `json:"msg_id"`
-	UserID   string         `json:"user_id"`
-	ChatCode string         `json:"chat_code"`
-	Emoji    EventEmojiItem `json:"emoji"`
-}
-type EventRichMessage struct {
-	GuildID     string     `json:"guild_id"`
-	Attachments Attachment `json:"attachments"`
-	Author      User       `json:"author"`
-}
 type EventCustomMessage struct {
+	Author       User           `json:"author"`
 	ChannelName  string         `json:"channel_name"`
+	GuildID      string         `json:"guild_id"`
+	Kmarkdown    EventKmarkdown `json:"kmarkdown"`
 	Mention      []string       `json:"mention"`
-	MentionRoles []int64        `json:"mention_roles"`
+	MentionAll   bool           `json:"mention_all"`
 	MentionHere  bool           `json:"mention_here"`
+	MentionRoles []int64        `json:"mention_roles"`
 	Quote        *Quote         `json:"quote"`
-	GuildID      string         `json:"guild_id"`
-	MentionAll   bool           `json:"mention_all"`
-	Author       User           `json:"author"`
-	Kmarkdown    EventKmarkdown `json:"kmarkdown"`
+}
+type EventDirectChatReactionItem struct {
+	ChatCode string         `json:"chat_code"`
+	Emoji    EventEmojiItem `json:"emoji"`
+	MsgID    string         `json:"msg_id"`
+	UserID   string         `json:"user_id"`
+}
+type EventEmojiItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 type EventGuild struct {
 	DefaultChannelID string          `json:"default_channel_id"`
-	WelcomeChannelID string          `json:"welcome_channel_id"`
-	Name             string          `json:"name"`
+	EnableOpen       IntBool         `json:"enable_open"`
+	ID               string          `json:"id"`
 	Icon             string          `json:"icon"`
+	Name             string          `json:"name"`
 	NotifyType       GuildNotifyType `json:"notify_type"`
-	Region           string          `json:"region"`
 	OpenID           int64           `json:"open_id"`
-	ID               string          `json:"id"`
+	Region           string          `json:"region"`
 	UserID           string          `json:"user_id"`
-	EnableOpen       IntBool         `json:"enable_open"`
+	WelcomeChannelID string          `json:"welcome_channel_id"`
 }
 type EventGuildMemberOnlineItem struct {
-	UserId    string         `json:"user_id"`
 	EventTime MilliTimeStamp `json:"event_time"`
 	Guilds    []string       `json:"guilds"`
+	UserId    string         `json:"user_id"`
 }
-type EventMentionRolePart struct {
-	RoleID int64  `json:"role_id"`
-	Name   string `json:"name"`
+type EventKmarkdown struct {
+	MentionPart     []EventMentionPart     `json:"mention_part"`
+	MentionRolePart []EventMentionRolePart `json:"mention_role_part"`
+	RawContent      string                 `json:"raw_content"`
 }
 type EventMentionPart struct {
+	Avatar   string `json:"avatar"`
+	FullName string `json:"full_name"`
 	ID       string `json:"id"`
 	Username string `json:"username"`
-	FullName string `json:"full_name"`
-	Avatar   string `json:"avatar"`
-}
-type EventKmarkdown struct {
-	RawContent      string                 `json:"raw_content"`
-	MentionPart     []EventMentionPart     `json:"mention_part"`
-	MentionRolePart []EventMentionRolePart `json:"mention_role_part"`
 }
-type EventEmojiItem struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+type EventMentionRolePart struct {
+	Name   string `json:"name"`
+	RoleID int64  `json:"role_id"`
 }
 type EventPinMessageItem struct {
 	ChannelID  string `json:"channel_id"`
-	OperatorID string `json:"operator_id"`
 	MsgID      string `json:"msg_id"`
+	OperatorID string `json:"operator_id"`
 }
-type GuildMemberUpdateEventHandler func(*GuildMemberUpdateContext)
+type EventReactionItem struct {
+	ChannelID string         `json:"channel_id"`
+	Emoji     EventEmojiItem `json:"emoji"`
+	MsgID     string         `json:"msg_id"`
+	UserID    string         `json:"user_id"`
+}
+type EventRichMessage struct {
+	Attachments Attachment `json:"attachments"`
+	Author      User       `json:"author"`
+	GuildID     string     `json:"guild_id"`
+}
+type TextMessageEventHandler func(*TextMessageContext)
 
-func (eh GuildMemberUpdateEventHandler) Type() string {
-	return "updated_guild_member"
+func (eh TextMessageEventHandler) Type() string {
+	return "1"
 }
-func (eh GuildMemberUpdateEventHandler) New() EventContext {
-	return &GuildMemberUpdateContext{}
+func (eh TextMessageEventHandler) New() EventContext {
+	return &TextMessageContext{}
 }
-func (eh GuildMemberUpdateEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*GuildMemberUpdateContext); ok {
+func (eh TextMessageEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*TextMessageContext); ok {
 		eh(t)
 	}
 }
 
-type GuildMemberUpdateContext struct {
+type TextMessageContext struct {
 	*EventHandlerCommonContext
-	Extra struct {
-		UserID   string `json:"user_id"`
-		Nickname string `json:"nickname"`
-	}
+	Extra EventCustomMessage
 }
 
-func (ctx *GuildMemberUpdateContext) GetExtra() interface{} {
+func (ctx *TextMessageContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *GuildMemberUpdateContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *TextMessageContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type GuildChannelMemberAddEventHandler func(*GuildChannelMemberAddContext)
+// Message returns the handle of the message of the event.
+func (ctx *TextMessageContext) Message() *Message {
+	return ctx.eventMessage(ctx.Common.MsgID, ctx.Common.TargetID, ctx.Common.AuthorID, ctx.Common.ChannelType == "PERSON")
+}
 
-func (eh GuildChannelMemberAddEventHandler) Type() string {
-	return "joined_channel"
+// ReplyCard sends a card message quoting the message of the event, to where the message is.
+func (ctx *TextMessageContext) ReplyCard(card CardMessage, options ...SendOption) (*Message, error) {
+	return ctx.Message().replyCard(card, options)
 }
-func (eh GuildChannelMemberAddEventHandler) New() EventContext {
-	return &GuildChannelMemberAddContext{}
+
+// ReplyTemp sends a temporary message only visible to the user of the event, which is only supported in channels.
+func (ctx *TextMessageContext) ReplyTemp(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, append(options, SendWithTempTarget(ctx.Common.AuthorID)))
 }
-func (eh GuildChannelMemberAddEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*GuildChannelMemberAddContext); ok {
-		eh(t)
-	}
+
+// React adds the emoji reaction to the message of the event.
+func (ctx *TextMessageContext) React(emoji string) error {
+	return ctx.Message().React(emoji)
 }
 
-type GuildChannelMemberAddContext struct {
-	*EventHandlerCommonContext
-	Extra struct {
-		UserID    string         `json:"user_id"`
-		ChannelID string         `json:"channel_id"`
-		JoinedAt  MilliTimeStamp `json:"joined_at"`
-	}
+// Delete deletes the message of the event.
+func (ctx *TextMessageContext) Delete() error {
+	return ctx.Message().Delete()
 }
 
-func (ctx *GuildChannelMemberAddContext) GetExtra() interface{} {
-	return &ctx.Extra
+// Edit replaces the content of the message of the event, which must be sent by the bot.
+func (ctx *TextMessageContext) Edit(content string, options ...SendOption) error {
+	return ctx.Message().Edit(content, options...)
 }
-func (ctx *GuildChannelMemberAddContext) GetCommon() *EventHandlerCommonContext {
-	if ctx.EventHandlerCommonContext == nil {
-		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
-	}
-	return ctx.EventHandlerCommonContext
+
+// MentionsUser reports whether the user is mentioned explicitly, not including MentionAll and MentionHere.
+func (ctx *TextMessageContext) MentionsUser(userID string) bool {
+	return ctx.Extra.MentionsUser(userID)
 }
 
-type UserUpdateEventHandler func(*UserUpdateContext)
+// MentionsBot reports whether the bot is mentioned explicitly.
+func (ctx *TextMessageContext) MentionsBot() bool {
+	return ctx.Extra.mentionsBot(ctx.Session)
+}
 
-func (eh UserUpdateEventHandler) Type() string {
-	return "user_updated"
+// MentionedUsers returns the users mentioned explicitly.
+func (ctx *TextMessageContext) MentionedUsers() []EventMentionPart {
+	return ctx.Extra.MentionedUsers()
 }
-func (eh UserUpdateEventHandler) New() EventContext {
-	return &UserUpdateContext{}
+
+// MentionedRoles returns the roles mentioned.
+func (ctx *TextMessageContext) MentionedRoles() []EventMentionRolePart {
+	return ctx.Extra.MentionedRoles()
 }
-func (eh UserUpdateEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*UserUpdateContext); ok {
+
+// StripMentions returns the content of the message without mentions of users and roles.
+func (ctx *TextMessageContext) StripMentions() string {
+	return StripMentions(ctx.Common.Content)
+}
+
+type EventCardMessageEventHandler func(*EventCardMessageContext)
+
+func (eh EventCardMessageEventHandler) Type() string {
+	return "10"
+}
+func (eh EventCardMessageEventHandler) New() EventContext {
+	return &EventCardMessageContext{}
+}
+func (eh EventCardMessageEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*EventCardMessageContext); ok {
 		eh(t)
 	}
 }
 
-type UserUpdateContext struct {
+type EventCardMessageContext struct {
 	*EventHandlerCommonContext
-	Extra struct {
-		UserID   string `json:"user_id"`
-		Username string `json:"username"`
-		Avatar   string `json:"avatar"`
-	}
+	Extra EventCustomMessage
 }
 
-func (ctx *UserUpdateContext) GetExtra() interface{} {
+func (ctx *EventCardMessageContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *UserUpdateContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *EventCardMessageContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type FileMessageEventHandler func(*FileMessageContext)
-
-func (eh FileMessageEventHandler) Type() string {
-	return "4"
-}
-func (eh FileMessageEventHandler) New() EventContext {
-	return &FileMessageContext{}
+// Message returns the handle of the message of the event.
+func (ctx *EventCardMessageContext) Message() *Message {
+	return ctx.eventMessage(ctx.Common.MsgID, ctx.Common.TargetID, ctx.Common.AuthorID, ctx.Common.ChannelType == "PERSON")
 }
-func (eh FileMessageEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*FileMessageContext); ok {
-		eh(t)
-	}
+
+// Reply sends a message quoting the message of the event, to where the message is.
+func (ctx *EventCardMessageContext) Reply(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, options)
 }
 
-type FileMessageContext struct {
-	*EventHandlerCommonContext
-	Extra EventRichMessage
+// ReplyCard sends a card message quoting the message of the event, to where the message is.
+func (ctx *EventCardMessageContext) ReplyCard(card CardMessage, options ...SendOption) (*Message, error) {
+	return ctx.Message().replyCard(card, options)
 }
 
-func (ctx *FileMessageContext) GetExtra() interface{} {
-	return &ctx.Extra
+// ReplyTemp sends a temporary message only visible to the user of the event, which is only supported in channels.
+func (ctx *EventCardMessageContext) ReplyTemp(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, append(options, SendWithTempTarget(ctx.Common.AuthorID)))
 }
-func (ctx *FileMessageContext) GetCommon() *EventHandlerCommonContext {
-	if ctx.EventHandlerCommonContext == nil {
-		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
-	}
-	return ctx.EventHandlerCommonContext
+
+// React adds the emoji reaction to the message of the event.
+func (ctx *EventCardMessageContext) React(emoji string) error {
+	return ctx.Message().React(emoji)
 }
 
-type MessageDeleteEventHandler func(*MessageDeleteContext)
+// Delete deletes the message of the event.
+func (ctx *EventCardMessageContext) Delete() error {
+	return ctx.Message().Delete()
+}
 
-func (eh MessageDeleteEventHandler) Type() string {
-	return "deleted_message"
+// Edit replaces the content of the message of the event, which must be sent by the bot.
+func (ctx *EventCardMessageContext) Edit(content string, options ...SendOption) error {
+	return ctx.Message().Edit(content, options...)
 }
-func (eh MessageDeleteEventHandler) New() EventContext {
-	return &MessageDeleteContext{}
+
+// MentionsUser reports whether the user is mentioned explicitly, not including MentionAll and MentionHere.
+func (ctx *EventCardMessageContext) MentionsUser(userID string) bool {
+	return ctx.Extra.MentionsUser(userID)
 }
-func (eh MessageDeleteEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*MessageDeleteContext); ok {
-		eh(t)
-	}
+
+// MentionsBot reports whether the bot is mentioned explicitly.
+func (ctx *EventCardMessageContext) MentionsBot() bool {
+	return ctx.Extra.mentionsBot(ctx.Session)
 }
 
-type MessageDeleteContext struct {
-	*EventHandlerCommonContext
-	Extra struct {
-		MsgID     string `json:"msg_id"`
-		ChannelID string `json:"channel_id"`
-	}
+// MentionedUsers returns the users mentioned explicitly.
+func (ctx *EventCardMessageContext) MentionedUsers() []EventMentionPart {
+	return ctx.Extra.MentionedUsers()
 }
 
-func (ctx *MessageDeleteContext) GetExtra() interface{} {
-	return &ctx.Extra
+// MentionedRoles returns the roles mentioned.
+func (ctx *EventCardMessageContext) MentionedRoles() []EventMentionRolePart {
+	return ctx.Extra.MentionedRoles()
 }
-func (ctx *MessageDeleteContext) GetCommon() *EventHandlerCommonContext {
-	if ctx.EventHandlerCommonContext == nil {
-		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
-	}
-	return ctx.EventHandlerCommonContext
+
+// StripMentions returns the content of the message without mentions of users and roles.
+func (ctx *EventCardMessageContext) StripMentions() string {
+	return StripMentions(ctx.Common.Content)
 }
 
-type ChannelDeleteEventHandler func(*ChannelDeleteContext)
+type ImageMessageEventHandler func(*ImageMessageContext)
 
-func (eh ChannelDeleteEventHandler) Type() string {
-	return "deleted_channel"
+func (eh ImageMessageEventHandler) Type() string {
+	return "2"
 }
-func (eh ChannelDeleteEventHandler) New() EventContext {
-	return &ChannelDeleteContext{}
+func (eh ImageMessageEventHandler) New() EventContext {
+	return &ImageMessageContext{}
 }
-func (eh ChannelDeleteEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*ChannelDeleteContext); ok {
+func (eh ImageMessageEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*ImageMessageContext); ok {
 		eh(t)
 	}
 }
 
-type ChannelDeleteContext struct {
+type ImageMessageContext struct {
 	*EventHandlerCommonContext
-	Extra struct {
-		ID        string         `json:"id"`
-		DeletedAt MilliTimeStamp `json:"deleted_at"`
-	}
+	Extra EventRichMessage
 }
 
-func (ctx *ChannelDeleteContext) GetExtra() interface{} {
+func (ctx *ImageMessageContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *ChannelDeleteContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *ImageMessageContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type MessagePinEventHandler func(*MessagePinContext)
+// Message returns the handle of the message of the event.
+func (ctx *ImageMessageContext) Message() *Message {
+	return ctx.eventMessage(ctx.Common.MsgID, ctx.Common.TargetID, ctx.Common.AuthorID, ctx.Common.ChannelType == "PERSON")
+}
 
-func (eh MessagePinEventHandler) Type() string {
-	return "pinned_message"
+// Reply sends a message quoting the message of the event, to where the message is.
+func (ctx *ImageMessageContext) Reply(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, options)
 }
-func (eh MessagePinEventHandler) New() EventContext {
-	return &MessagePinContext{}
+
+// ReplyCard sends a card message quoting the message of the event, to where the message is.
+func (ctx *ImageMessageContext) ReplyCard(card CardMessage, options ...SendOption) (*Message, error) {
+	return ctx.Message().replyCard(card, options)
 }
-func (eh MessagePinEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*MessagePinContext); ok {
-		eh(t)
-	}
+
+// ReplyTemp sends a temporary message only visible to the user of the event, which is only supported in channels.
+func (ctx *ImageMessageContext) ReplyTemp(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, append(options, SendWithTempTarget(ctx.Common.AuthorID)))
 }
 
-type MessagePinContext struct {
-	*EventHandlerCommonContext
-	Extra EventPinMessageItem
-}
-
-func (ctx *MessagePinContext) GetExtra() interface{} {
-	return &ctx.Extra
-}
-func (ctx *MessagePinContext) GetCommon() *EventHandlerCommonContext {
-	if ctx.EventHandlerCommonContext == nil {
-		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
-	}
-	return ctx.EventHandlerCommonContext
-}
-
-type BotExitEventHandler func(*BotExitContext)
-
-func (eh BotExitEventHandler) Type() string {
-	return "self_exited_guild"
-}
-func (eh BotExitEventHandler) New() EventContext {
-	return &BotExitContext{}
-}
-func (eh BotExitEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*BotExitContext); ok {
-		eh(t)
-	}
+// React adds the emoji reaction to the message of the event.
+func (ctx *ImageMessageContext) React(emoji string) error {
+	return ctx.Message().React(emoji)
 }
 
-type BotExitContext struct {
-	*EventHandlerCommonContext
-	Extra struct {
-		GuildID string `json:"guild_id"`
-	}
+// Delete deletes the message of the event.
+func (ctx *ImageMessageContext) Delete() error {
+	return ctx.Message().Delete()
 }
 
-func (ctx *BotExitContext) GetExtra() interface{} {
-	return &ctx.Extra
-}
-func (ctx *BotExitContext) GetCommon() *EventHandlerCommonContext {
-	if ctx.EventHandlerCommonContext == nil {
-		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
-	}
-	return ctx.EventHandlerCommonContext
+// Edit replaces the content of the message of the event, which must be sent by the bot.
+func (ctx *ImageMessageContext) Edit(content string, options ...SendOption) error {
+	return ctx.Message().Edit(content, options...)
 }
 
 type VideoMessageEventHandler func(*VideoMessageContext)
@@ -355,646 +341,684 @@ func (ctx *VideoMessageContext) GetCommon() *EventHandlerCommonContext {
 	return ctx.EventHandlerCommonContext
 }
 
-type EventCardMessageEventHandler func(*EventCardMessageContext)
+// Message returns the handle of the message of the event.
+func (ctx *VideoMessageContext) Message() *Message {
+	return ctx.eventMessage(ctx.Common.MsgID, ctx.Common.TargetID, ctx.Common.AuthorID, ctx.Common.ChannelType == "PERSON")
+}
 
-func (eh EventCardMessageEventHandler) Type() string {
-	return "10"
+// Reply sends a message quoting the message of the event, to where the message is.
+func (ctx *VideoMessageContext) Reply(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, options)
 }
-func (eh EventCardMessageEventHandler) New() EventContext {
-	return &EventCardMessageContext{}
+
+// ReplyCard sends a card message quoting the message of the event, to where the message is.
+func (ctx *VideoMessageContext) ReplyCard(card CardMessage, options ...SendOption) (*Message, error) {
+	return ctx.Message().replyCard(card, options)
 }
-func (eh EventCardMessageEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*EventCardMessageContext); ok {
-		eh(t)
-	}
+
+// ReplyTemp sends a temporary message only visible to the user of the event, which is only supported in channels.
+func (ctx *VideoMessageContext) ReplyTemp(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, append(options, SendWithTempTarget(ctx.Common.AuthorID)))
 }
 
-type EventCardMessageContext struct {
-	*EventHandlerCommonContext
-	Extra EventCustomMessage
+// React adds the emoji reaction to the message of the event.
+func (ctx *VideoMessageContext) React(emoji string) error {
+	return ctx.Message().React(emoji)
 }
 
-func (ctx *EventCardMessageContext) GetExtra() interface{} {
-	return &ctx.Extra
+// Delete deletes the message of the event.
+func (ctx *VideoMessageContext) Delete() error {
+	return ctx.Message().Delete()
 }
-func (ctx *EventCardMessageContext) GetCommon() *EventHandlerCommonContext {
-	if ctx.EventHandlerCommonContext == nil {
-		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
-	}
-	return ctx.EventHandlerCommonContext
+
+// Edit replaces the content of the message of the event, which must be sent by the bot.
+func (ctx *VideoMessageContext) Edit(content string, options ...SendOption) error {
+	return ctx.Message().Edit(content, options...)
 }
 
-type ReactionAddEventHandler func(*ReactionAddContext)
+type FileMessageEventHandler func(*FileMessageContext)
 
-func (eh ReactionAddEventHandler) Type() string {
-	return "added_reaction"
+func (eh FileMessageEventHandler) Type() string {
+	return "4"
 }
-func (eh ReactionAddEventHandler) New() EventContext {
-	return &ReactionAddContext{}
+func (eh FileMessageEventHandler) New() EventContext {
+	return &FileMessageContext{}
 }
-func (eh ReactionAddEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*ReactionAddContext); ok {
+func (eh FileMessageEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*FileMessageContext); ok {
 		eh(t)
 	}
 }
 
-type ReactionAddContext struct {
+type FileMessageContext struct {
 	*EventHandlerCommonContext
-	Extra EventReactionItem
+	Extra EventRichMessage
 }
 
-func (ctx *ReactionAddContext) GetExtra() interface{} {
+func (ctx *FileMessageContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *ReactionAddContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *FileMessageContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type GuildMemberAddEventHandler func(*GuildMemberAddContext)
+// Message returns the handle of the message of the event.
+func (ctx *FileMessageContext) Message() *Message {
+	return ctx.eventMessage(ctx.Common.MsgID, ctx.Common.TargetID, ctx.Common.AuthorID, ctx.Common.ChannelType == "PERSON")
+}
 
-func (eh GuildMemberAddEventHandler) Type() string {
-	return "joined_guild"
+// Reply sends a message quoting the message of the event, to where the message is.
+func (ctx *FileMessageContext) Reply(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, options)
 }
-func (eh GuildMemberAddEventHandler) New() EventContext {
-	return &GuildMemberAddContext{}
+
+// ReplyCard sends a card message quoting the message of the event, to where the message is.
+func (ctx *FileMessageContext) ReplyCard(card CardMessage, options ...SendOption) (*Message, error) {
+	return ctx.Message().replyCard(card, options)
 }
-func (eh GuildMemberAddEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*GuildMemberAddContext); ok {
-		eh(t)
-	}
+
+// ReplyTemp sends a temporary message only visible to the user of the event, which is only supported in channels.
+func (ctx *FileMessageContext) ReplyTemp(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, append(options, SendWithTempTarget(ctx.Common.AuthorID)))
 }
 
-type GuildMemberAddContext struct {
-	*EventHandlerCommonContext
-	Extra struct {
-		UserID   string         `json:"user_id"`
-		JoinedAt MilliTimeStamp `json:"joined_at"`
-	}
+// React adds the emoji reaction to the message of the event.
+func (ctx *FileMessageContext) React(emoji string) error {
+	return ctx.Message().React(emoji)
 }
 
-func (ctx *GuildMemberAddContext) GetExtra() interface{} {
-	return &ctx.Extra
+// Delete deletes the message of the event.
+func (ctx *FileMessageContext) Delete() error {
+	return ctx.Message().Delete()
 }
-func (ctx *GuildMemberAddContext) GetCommon() *EventHandlerCommonContext {
-	if ctx.EventHandlerCommonContext == nil {
-		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
-	}
-	return ctx.EventHandlerCommonContext
+
+// Edit replaces the content of the message of the event, which must be sent by the bot.
+func (ctx *FileMessageContext) Edit(content string, options ...SendOption) error {
+	return ctx.Message().Edit(content, options...)
 }
 
-type GuildChannelMemberDeleteEventHandler func(*GuildChannelMemberDeleteContext)
+type AudioMessageEventHandler func(*AudioMessageContext)
 
-func (eh GuildChannelMemberDeleteEventHandler) Type() string {
-	return "exited_channel"
+func (eh AudioMessageEventHandler) Type() string {
+	return "8"
 }
-func (eh GuildChannelMemberDeleteEventHandler) New() EventContext {
-	return &GuildChannelMemberDeleteContext{}
+func (eh AudioMessageEventHandler) New() EventContext {
+	return &AudioMessageContext{}
 }
-func (eh GuildChannelMemberDeleteEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*GuildChannelMemberDeleteContext); ok {
+func (eh AudioMessageEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*AudioMessageContext); ok {
 		eh(t)
 	}
 }
 
-type GuildChannelMemberDeleteContext struct {
+type AudioMessageContext struct {
 	*EventHandlerCommonContext
-	Extra struct {
-		UserID    string         `json:"user_id"`
-		ChannelID string         `json:"channel_id"`
-		ExitedAt  MilliTimeStamp `json:"exited_at"`
-	}
+	Extra EventRichMessage
 }
 
-func (ctx *GuildChannelMemberDeleteContext) GetExtra() interface{} {
+func (ctx *AudioMessageContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *GuildChannelMemberDeleteContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *AudioMessageContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type GuildMemberDeleteEventHandler func(*GuildMemberDeleteContext)
+// Message returns the handle of the message of the event.
+func (ctx *AudioMessageContext) Message() *Message {
+	return ctx.eventMessage(ctx.Common.MsgID, ctx.Common.TargetID, ctx.Common.AuthorID, ctx.Common.ChannelType == "PERSON")
+}
 
-func (eh GuildMemberDeleteEventHandler) Type() string {
-	return "exited_guild"
+// Reply sends a message quoting the message of the event, to where the message is.
+func (ctx *AudioMessageContext) Reply(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, options)
 }
-func (eh GuildMemberDeleteEventHandler) New() EventContext {
-	return &GuildMemberDeleteContext{}
+
+// ReplyCard sends a card message quoting the message of the event, to where the message is.
+func (ctx *AudioMessageContext) ReplyCard(card CardMessage, options ...SendOption) (*Message, error) {
+	return ctx.Message().replyCard(card, options)
 }
-func (eh GuildMemberDeleteEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*GuildMemberDeleteContext); ok {
-		eh(t)
-	}
+
+// ReplyTemp sends a temporary message only visible to the user of the event, which is only supported in channels.
+func (ctx *AudioMessageContext) ReplyTemp(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, append(options, SendWithTempTarget(ctx.Common.AuthorID)))
 }
 
-type GuildMemberDeleteContext struct {
-	*EventHandlerCommonContext
-	Extra struct {
-		UserID   string         `json:"user_id"`
-		ExitedAt MilliTimeStamp `json:"exited_at"`
-	}
+// React adds the emoji reaction to the message of the event.
+func (ctx *AudioMessageContext) React(emoji string) error {
+	return ctx.Message().React(emoji)
 }
 
-func (ctx *GuildMemberDeleteContext) GetExtra() interface{} {
-	return &ctx.Extra
+// Delete deletes the message of the event.
+func (ctx *AudioMessageContext) Delete() error {
+	return ctx.Message().Delete()
 }
-func (ctx *GuildMemberDeleteContext) GetCommon() *EventHandlerCommonContext {
-	if ctx.EventHandlerCommonContext == nil {
-		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
-	}
-	return ctx.EventHandlerCommonContext
+
+// Edit replaces the content of the message of the event, which must be sent by the bot.
+func (ctx *AudioMessageContext) Edit(content string, options ...SendOption) error {
+	return ctx.Message().Edit(content, options...)
 }
 
-type GuildRoleAddEventHandler func(*GuildRoleAddContext)
+type KmarkdownMessageEventHandler func(*KmarkdownMessageContext)
 
-func (eh GuildRoleAddEventHandler) Type() string {
-	return "added_role"
+func (eh KmarkdownMessageEventHandler) Type() string {
+	return "9"
 }
-func (eh GuildRoleAddEventHandler) New() EventContext {
-	return &GuildRoleAddContext{}
+func (eh KmarkdownMessageEventHandler) New() EventContext {
+	return &KmarkdownMessageContext{}
 }
-func (eh GuildRoleAddEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*GuildRoleAddContext); ok {
+func (eh KmarkdownMessageEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*KmarkdownMessageContext); ok {
 		eh(t)
 	}
 }
 
-type GuildRoleAddContext struct {
+type KmarkdownMessageContext struct {
 	*EventHandlerCommonContext
-	Extra Role
+	Extra EventCustomMessage
 }
 
-func (ctx *GuildRoleAddContext) GetExtra() interface{} {
+func (ctx *KmarkdownMessageContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *GuildRoleAddContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *KmarkdownMessageContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type GuildRoleDeleteEventHandler func(*GuildRoleDeleteContext)
+// Message returns the handle of the message of the event.
+func (ctx *KmarkdownMessageContext) Message() *Message {
+	return ctx.eventMessage(ctx.Common.MsgID, ctx.Common.TargetID, ctx.Common.AuthorID, ctx.Common.ChannelType == "PERSON")
+}
 
-func (eh GuildRoleDeleteEventHandler) Type() string {
-	return "deleted_role"
+// Reply sends a message quoting the message of the event, to where the message is.
+func (ctx *KmarkdownMessageContext) Reply(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, options)
 }
-func (eh GuildRoleDeleteEventHandler) New() EventContext {
-	return &GuildRoleDeleteContext{}
+
+// ReplyCard sends a card message quoting the message of the event, to where the message is.
+func (ctx *KmarkdownMessageContext) ReplyCard(card CardMessage, options ...SendOption) (*Message, error) {
+	return ctx.Message().replyCard(card, options)
 }
-func (eh GuildRoleDeleteEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*GuildRoleDeleteContext); ok {
-		eh(t)
-	}
+
+// ReplyTemp sends a temporary message only visible to the user of the event, which is only supported in channels.
+func (ctx *KmarkdownMessageContext) ReplyTemp(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, append(options, SendWithTempTarget(ctx.Common.AuthorID)))
 }
 
-type GuildRoleDeleteContext struct {
-	*EventHandlerCommonContext
-	Extra Role
+// React adds the emoji reaction to the message of the event.
+func (ctx *KmarkdownMessageContext) React(emoji string) error {
+	return ctx.Message().React(emoji)
 }
 
-func (ctx *GuildRoleDeleteContext) GetExtra() interface{} {
-	return &ctx.Extra
+// Delete deletes the message of the event.
+func (ctx *KmarkdownMessageContext) Delete() error {
+	return ctx.Message().Delete()
 }
-func (ctx *GuildRoleDeleteContext) GetCommon() *EventHandlerCommonContext {
-	if ctx.EventHandlerCommonContext == nil {
-		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
-	}
-	return ctx.EventHandlerCommonContext
+
+// Edit replaces the content of the message of the event, which must be sent by the bot.
+func (ctx *KmarkdownMessageContext) Edit(content string, options ...SendOption) error {
+	return ctx.Message().Edit(content, options...)
 }
 
-type MessageButtonClickEventHandler func(*MessageButtonClickContext)
+// MentionsUser reports whether the user is mentioned explicitly, not including MentionAll and MentionHere.
+func (ctx *KmarkdownMessageContext) MentionsUser(userID string) bool {
+	return ctx.Extra.MentionsUser(userID)
+}
 
-func (eh MessageButtonClickEventHandler) Type() string {
-	return "message_btn_click"
+// MentionsBot reports whether the bot is mentioned explicitly.
+func (ctx *KmarkdownMessageContext) MentionsBot() bool {
+	return ctx.Extra.mentionsBot(ctx.Session)
 }
-func (eh MessageButtonClickEventHandler) New() EventContext {
-	return &MessageButtonClickContext{}
+
+// MentionedUsers returns the users mentioned explicitly.
+func (ctx *KmarkdownMessageContext) MentionedUsers() []EventMentionPart {
+	return ctx.Extra.MentionedUsers()
 }
-func (eh MessageButtonClickEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*MessageButtonClickContext); ok {
+
+// MentionedRoles returns the roles mentioned.
+func (ctx *KmarkdownMessageContext) MentionedRoles() []EventMentionRolePart {
+	return ctx.Extra.MentionedRoles()
+}
+
+// StripMentions returns the content of the message without mentions of users and roles.
+func (ctx *KmarkdownMessageContext) StripMentions() string {
+	return StripMentions(ctx.Common.Content)
+}
+
+type BlockListAddEventHandler func(*BlockListAddContext)
+
+func (eh BlockListAddEventHandler) Type() string {
+	return "added_block_list"
+}
+func (eh BlockListAddEventHandler) New() EventContext {
+	return &BlockListAddContext{}
+}
+func (eh BlockListAddEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*BlockListAddContext); ok {
 		eh(t)
 	}
 }
 
-type MessageButtonClickContext struct {
+type BlockListAddContext struct {
 	*EventHandlerCommonContext
 	Extra struct {
-		UserInfo User   `json:"user_info"`
-		MsgID    string `json:"msg_id"`
-		UserID   string `json:"user_id"`
-		Value    string `json:"value"`
-		TargetID string `json:"target_id"`
-		GuildID  string `json:"guild_id"`
+		OperatorID string   `json:"operator_id"`
+		Remark     string   `json:"remark"`
+		UserID     []string `json:"user_id"`
 	}
 }
 
-func (ctx *MessageButtonClickContext) GetExtra() interface{} {
+func (ctx *BlockListAddContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *MessageButtonClickContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *BlockListAddContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type EmojiAddedEventHandler func(*EmojiAddedContext)
+type ChannelAddEventHandler func(*ChannelAddContext)
 
-func (eh EmojiAddedEventHandler) Type() string {
-	return "added_emoji"
-}
-func (eh EmojiAddedEventHandler) New() EventContext {
-	return &EmojiAddedContext{}
+func (eh ChannelAddEventHandler) Type() string {
+	return "added_channel"
 }
-func (eh EmojiAddedEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*EmojiAddedContext); ok {
+func (eh ChannelAddEventHandler) New() EventContext {
+	return &ChannelAddContext{}
+}
+func (eh ChannelAddEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*ChannelAddContext); ok {
 		eh(t)
 	}
 }
 
-type EmojiAddedContext struct {
+type ChannelAddContext struct {
 	*EventHandlerCommonContext
-	Extra EventEmojiItem
+	Extra Channel
 }
 
-func (ctx *EmojiAddedContext) GetExtra() interface{} {
+func (ctx *ChannelAddContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *EmojiAddedContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *ChannelAddContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type GuildDeleteEventHandler func(*GuildDeleteContext)
+type EmojiAddedEventHandler func(*EmojiAddedContext)
 
-func (eh GuildDeleteEventHandler) Type() string {
-	return "deleted_guild"
+func (eh EmojiAddedEventHandler) Type() string {
+	return "added_emoji"
 }
-func (eh GuildDeleteEventHandler) New() EventContext {
-	return &GuildDeleteContext{}
+func (eh EmojiAddedEventHandler) New() EventContext {
+	return &EmojiAddedContext{}
 }
-func (eh GuildDeleteEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*GuildDeleteContext); ok {
+func (eh EmojiAddedEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*EmojiAddedContext); ok {
 		eh(t)
 	}
 }
 
-type GuildDeleteContext struct {
+type EmojiAddedContext struct {
 	*EventHandlerCommonContext
-	Extra EventGuild
+	Extra EventEmojiItem
 }
 
-func (ctx *GuildDeleteContext) GetExtra() interface{} {
+func (ctx *EmojiAddedContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *GuildDeleteContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *EmojiAddedContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type ChannelUpdateEventHandler func(*ChannelUpdateContext)
+type ReactionAddEventHandler func(*ReactionAddContext)
 
-func (eh ChannelUpdateEventHandler) Type() string {
-	return "updated_channel"
+func (eh ReactionAddEventHandler) Type() string {
+	return "added_reaction"
 }
-func (eh ChannelUpdateEventHandler) New() EventContext {
-	return &ChannelUpdateContext{}
+func (eh ReactionAddEventHandler) New() EventContext {
+	return &ReactionAddContext{}
 }
-func (eh ChannelUpdateEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*ChannelUpdateContext); ok {
+func (eh ReactionAddEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*ReactionAddContext); ok {
 		eh(t)
 	}
 }
 
-type ChannelUpdateContext struct {
+type ReactionAddContext struct {
 	*EventHandlerCommonContext
-	Extra Channel
+	Extra EventReactionItem
 }
 
-func (ctx *ChannelUpdateContext) GetExtra() interface{} {
+func (ctx *ReactionAddContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *ChannelUpdateContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *ReactionAddContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type DirectMessageDeleteEventHandler func(*DirectMessageDeleteContext)
+type GuildRoleAddEventHandler func(*GuildRoleAddContext)
 
-func (eh DirectMessageDeleteEventHandler) Type() string {
-	return "deleted_private_message"
+func (eh GuildRoleAddEventHandler) Type() string {
+	return "added_role"
 }
-func (eh DirectMessageDeleteEventHandler) New() EventContext {
-	return &DirectMessageDeleteContext{}
+func (eh GuildRoleAddEventHandler) New() EventContext {
+	return &GuildRoleAddContext{}
 }
-func (eh DirectMessageDeleteEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*DirectMessageDeleteContext); ok {
+func (eh GuildRoleAddEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*GuildRoleAddContext); ok {
 		eh(t)
 	}
 }
 
-type DirectMessageDeleteContext struct {
+type GuildRoleAddContext struct {
 	*EventHandlerCommonContext
-	Extra struct {
-		MsgID     string         `json:"msg_id"`
-		AuthorID  string         `json:"author_id"`
-		TargetID  string         `json:"target_id"`
-		ChatCode  string         `json:"chat_code"`
-		DeletedAt MilliTimeStamp `json:"deleted_at"`
-	}
+	Extra Role
 }
 
-func (ctx *DirectMessageDeleteContext) GetExtra() interface{} {
+func (ctx *GuildRoleAddContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *DirectMessageDeleteContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *GuildRoleAddContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type DirectMessageReactionAddEventHandler func(*DirectMessageReactionAddContext)
+type BlockListDeleteEventHandler func(*BlockListDeleteContext)
 
-func (eh DirectMessageReactionAddEventHandler) Type() string {
-	return "private_added_reaction"
+func (eh BlockListDeleteEventHandler) Type() string {
+	return "deleted_block_list"
 }
-func (eh DirectMessageReactionAddEventHandler) New() EventContext {
-	return &DirectMessageReactionAddContext{}
+func (eh BlockListDeleteEventHandler) New() EventContext {
+	return &BlockListDeleteContext{}
 }
-func (eh DirectMessageReactionAddEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*DirectMessageReactionAddContext); ok {
+func (eh BlockListDeleteEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*BlockListDeleteContext); ok {
 		eh(t)
 	}
 }
 
-type DirectMessageReactionAddContext struct {
+type BlockListDeleteContext struct {
 	*EventHandlerCommonContext
-	Extra EventDirectChatReactionItem
+	Extra struct {
+		OperatorID string   `json:"operator_id"`
+		UserID     []string `json:"user_id"`
+	}
 }
 
-func (ctx *DirectMessageReactionAddContext) GetExtra() interface{} {
+func (ctx *BlockListDeleteContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *DirectMessageReactionAddContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *BlockListDeleteContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type GuildMemberOnlineEventHandler func(*GuildMemberOnlineContext)
+type ChannelDeleteEventHandler func(*ChannelDeleteContext)
 
-func (eh GuildMemberOnlineEventHandler) Type() string {
-	return "guild_member_online"
+func (eh ChannelDeleteEventHandler) Type() string {
+	return "deleted_channel"
 }
-func (eh GuildMemberOnlineEventHandler) New() EventContext {
-	return &GuildMemberOnlineContext{}
+func (eh ChannelDeleteEventHandler) New() EventContext {
+	return &ChannelDeleteContext{}
 }
-func (eh GuildMemberOnlineEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*GuildMemberOnlineContext); ok {
+func (eh ChannelDeleteEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*ChannelDeleteContext); ok {
 		eh(t)
 	}
 }
 
-type GuildMemberOnlineContext struct {
+type ChannelDeleteContext struct {
 	*EventHandlerCommonContext
-	Extra EventGuildMemberOnlineItem
+	Extra struct {
+		DeletedAt MilliTimeStamp `json:"deleted_at"`
+		ID        string         `json:"id"`
+	}
 }
 
-func (ctx *GuildMemberOnlineContext) GetExtra() interface{} {
+func (ctx *ChannelDeleteContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *GuildMemberOnlineContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *ChannelDeleteContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type ImageMessageEventHandler func(*ImageMessageContext)
+type GuildDeleteEventHandler func(*GuildDeleteContext)
 
-func (eh ImageMessageEventHandler) Type() string {
-	return "2"
+func (eh GuildDeleteEventHandler) Type() string {
+	return "deleted_guild"
 }
-func (eh ImageMessageEventHandler) New() EventContext {
-	return &ImageMessageContext{}
+func (eh GuildDeleteEventHandler) New() EventContext {
+	return &GuildDeleteContext{}
 }
-func (eh ImageMessageEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*ImageMessageContext); ok {
+func (eh GuildDeleteEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*GuildDeleteContext); ok {
 		eh(t)
 	}
 }
 
-type ImageMessageContext struct {
+type GuildDeleteContext struct {
 	*EventHandlerCommonContext
-	Extra EventRichMessage
+	Extra EventGuild
 }
 
-func (ctx *ImageMessageContext) GetExtra() interface{} {
+func (ctx *GuildDeleteContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *ImageMessageContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *GuildDeleteContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type AudioMessageEventHandler func(*AudioMessageContext)
+type MessageDeleteEventHandler func(*MessageDeleteContext)
 
-func (eh AudioMessageEventHandler) Type() string {
-	return "8"
+func (eh MessageDeleteEventHandler) Type() string {
+	return "deleted_message"
 }
-func (eh AudioMessageEventHandler) New() EventContext {
-	return &AudioMessageContext{}
+func (eh MessageDeleteEventHandler) New() EventContext {
+	return &MessageDeleteContext{}
 }
-func (eh AudioMessageEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*AudioMessageContext); ok {
+func (eh MessageDeleteEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*MessageDeleteContext); ok {
 		eh(t)
 	}
 }
 
-type AudioMessageContext struct {
+type MessageDeleteContext struct {
 	*EventHandlerCommonContext
-	Extra EventRichMessage
+	Extra struct {
+		ChannelID string `json:"channel_id"`
+		MsgID     string `json:"msg_id"`
+	}
 }
 
-func (ctx *AudioMessageContext) GetExtra() interface{} {
+func (ctx *MessageDeleteContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *AudioMessageContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *MessageDeleteContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type BlockListAddEventHandler func(*BlockListAddContext)
+type DirectMessageDeleteEventHandler func(*DirectMessageDeleteContext)
 
-func (eh BlockListAddEventHandler) Type() string {
-	return "added_block_list"
+func (eh DirectMessageDeleteEventHandler) Type() string {
+	return "deleted_private_message"
 }
-func (eh BlockListAddEventHandler) New() EventContext {
-	return &BlockListAddContext{}
+func (eh DirectMessageDeleteEventHandler) New() EventContext {
+	return &DirectMessageDeleteContext{}
 }
-func (eh BlockListAddEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*BlockListAddContext); ok {
+func (eh DirectMessageDeleteEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*DirectMessageDeleteContext); ok {
 		eh(t)
 	}
 }
 
-type BlockListAddContext struct {
+type DirectMessageDeleteContext struct {
 	*EventHandlerCommonContext
 	Extra struct {
-		OperatorID string   `json:"operator_id"`
-		Remark     string   `json:"remark"`
-		UserID     []string `json:"user_id"`
+		AuthorID  string         `json:"author_id"`
+		ChatCode  string         `json:"chat_code"`
+		DeletedAt MilliTimeStamp `json:"deleted_at"`
+		MsgID     string         `json:"msg_id"`
+		TargetID  string         `json:"target_id"`
 	}
 }
 
-func (ctx *BlockListAddContext) GetExtra() interface{} {
+func (ctx *DirectMessageDeleteContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *BlockListAddContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *DirectMessageDeleteContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type BlockListDeleteEventHandler func(*BlockListDeleteContext)
+type ReactionDeleteEventHandler func(*ReactionDeleteContext)
 
-func (eh BlockListDeleteEventHandler) Type() string {
-	return "deleted_block_list"
+func (eh ReactionDeleteEventHandler) Type() string {
+	return "deleted_reaction"
 }
-func (eh BlockListDeleteEventHandler) New() EventContext {
-	return &BlockListDeleteContext{}
+func (eh ReactionDeleteEventHandler) New() EventContext {
+	return &ReactionDeleteContext{}
 }
-func (eh BlockListDeleteEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*BlockListDeleteContext); ok {
+func (eh ReactionDeleteEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*ReactionDeleteContext); ok {
 		eh(t)
 	}
 }
 
-type BlockListDeleteContext struct {
+type ReactionDeleteContext struct {
 	*EventHandlerCommonContext
-	Extra struct {
-		OperatorID string   `json:"operator_id"`
-		UserID     []string `json:"user_id"`
-	}
+	Extra EventReactionItem
 }
 
-func (ctx *BlockListDeleteContext) GetExtra() interface{} {
+func (ctx *ReactionDeleteContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *BlockListDeleteContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *ReactionDeleteContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type DirectMessageUpdateEventHandler func(*DirectMessageUpdateContext)
+type GuildRoleDeleteEventHandler func(*GuildRoleDeleteContext)
 
-func (eh DirectMessageUpdateEventHandler) Type() string {
-	return "updated_private_message"
+func (eh GuildRoleDeleteEventHandler) Type() string {
+	return "deleted_role"
 }
-func (eh DirectMessageUpdateEventHandler) New() EventContext {
-	return &DirectMessageUpdateContext{}
+func (eh GuildRoleDeleteEventHandler) New() EventContext {
+	return &GuildRoleDeleteContext{}
 }
-func (eh DirectMessageUpdateEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*DirectMessageUpdateContext); ok {
+func (eh GuildRoleDeleteEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*GuildRoleDeleteContext); ok {
 		eh(t)
 	}
 }
 
-type DirectMessageUpdateContext struct {
+type GuildRoleDeleteContext struct {
 	*EventHandlerCommonContext
-	Extra struct {
-		Content   string         `json:"content"`
-		ChatCode  string         `json:"chat_code"`
-		UpdatedAt MilliTimeStamp `json:"updated_at"`
-		MsgID     string         `json:"msg_id"`
-		AuthorID  string         `json:"author_id"`
-		TargetID  string         `json:"target_id"`
-	}
+	Extra Role
 }
 
-func (ctx *DirectMessageUpdateContext) GetExtra() interface{} {
+func (ctx *GuildRoleDeleteContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *DirectMessageUpdateContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *GuildRoleDeleteContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type KmarkdownMessageEventHandler func(*KmarkdownMessageContext)
+type GuildChannelMemberDeleteEventHandler func(*GuildChannelMemberDeleteContext)
 
-func (eh KmarkdownMessageEventHandler) Type() string {
-	return "9"
+func (eh GuildChannelMemberDeleteEventHandler) Type() string {
+	return "exited_channel"
 }
-func (eh KmarkdownMessageEventHandler) New() EventContext {
-	return &KmarkdownMessageContext{}
+func (eh GuildChannelMemberDeleteEventHandler) New() EventContext {
+	return &GuildChannelMemberDeleteContext{}
 }
-func (eh KmarkdownMessageEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*KmarkdownMessageContext); ok {
+func (eh GuildChannelMemberDeleteEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*GuildChannelMemberDeleteContext); ok {
 		eh(t)
 	}
 }
 
-type KmarkdownMessageContext struct {
+type GuildChannelMemberDeleteContext struct {
 	*EventHandlerCommonContext
-	Extra EventCustomMessage
+	Extra struct {
+		ChannelID string         `json:"channel_id"`
+		ExitedAt  MilliTimeStamp `json:"exited_at"`
+		UserID    string         `json:"user_id"`
+	}
 }
 
-func (ctx *KmarkdownMessageContext) GetExtra() interface{} {
+func (ctx *GuildChannelMemberDeleteContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *KmarkdownMessageContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *GuildChannelMemberDeleteContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type DirectMessageReactionDeleteEventHandler func(*DirectMessageReactionDeleteContext)
+type GuildMemberDeleteEventHandler func(*GuildMemberDeleteContext)
 
-func (eh DirectMessageReactionDeleteEventHandler) Type() string {
-	return "private_deleted_reaction"
+func (eh GuildMemberDeleteEventHandler) Type() string {
+	return "exited_guild"
 }
-func (eh DirectMessageReactionDeleteEventHandler) New() EventContext {
-	return &DirectMessageReactionDeleteContext{}
+func (eh GuildMemberDeleteEventHandler) New() EventContext {
+	return &GuildMemberDeleteContext{}
 }
-func (eh DirectMessageReactionDeleteEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*DirectMessageReactionDeleteContext); ok {
+func (eh GuildMemberDeleteEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*GuildMemberDeleteContext); ok {
 		eh(t)
 	}
 }
 
-type DirectMessageReactionDeleteContext struct {
+type GuildMemberDeleteContext struct {
 	*EventHandlerCommonContext
-	Extra EventDirectChatReactionItem
+	Extra struct {
+		ExitedAt MilliTimeStamp `json:"exited_at"`
+		UserID   string         `json:"user_id"`
+	}
 }
 
-func (ctx *DirectMessageReactionDeleteContext) GetExtra() interface{} {
+func (ctx *GuildMemberDeleteContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *DirectMessageReactionDeleteContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *GuildMemberDeleteContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
@@ -1030,252 +1054,343 @@ func (ctx *GuildMemberOfflineContext) GetCommon() *EventHandlerCommonContext {
 	return ctx.EventHandlerCommonContext
 }
 
-type EmojiUpdatedEventHandler func(*EmojiUpdatedContext)
+type GuildMemberOnlineEventHandler func(*GuildMemberOnlineContext)
 
-func (eh EmojiUpdatedEventHandler) Type() string {
-	return "updated_emoji"
+func (eh GuildMemberOnlineEventHandler) Type() string {
+	return "guild_member_online"
 }
-func (eh EmojiUpdatedEventHandler) New() EventContext {
-	return &EmojiUpdatedContext{}
+func (eh GuildMemberOnlineEventHandler) New() EventContext {
+	return &GuildMemberOnlineContext{}
 }
-func (eh EmojiUpdatedEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*EmojiUpdatedContext); ok {
+func (eh GuildMemberOnlineEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*GuildMemberOnlineContext); ok {
 		eh(t)
 	}
 }
 
-type EmojiUpdatedContext struct {
+type GuildMemberOnlineContext struct {
 	*EventHandlerCommonContext
-	Extra EventEmojiItem
+	Extra EventGuildMemberOnlineItem
 }
 
-func (ctx *EmojiUpdatedContext) GetExtra() interface{} {
+func (ctx *GuildMemberOnlineContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *EmojiUpdatedContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *GuildMemberOnlineContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type TextMessageEventHandler func(*TextMessageContext)
+type GuildChannelMemberAddEventHandler func(*GuildChannelMemberAddContext)
 
-func (eh TextMessageEventHandler) Type() string {
-	return "1"
+func (eh GuildChannelMemberAddEventHandler) Type() string {
+	return "joined_channel"
 }
-func (eh TextMessageEventHandler) New() EventContext {
-	return &TextMessageContext{}
+func (eh GuildChannelMemberAddEventHandler) New() EventContext {
+	return &GuildChannelMemberAddContext{}
 }
-func (eh TextMessageEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*TextMessageContext); ok {
+func (eh GuildChannelMemberAddEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*GuildChannelMemberAddContext); ok {
 		eh(t)
 	}
 }
 
-type TextMessageContext struct {
+type GuildChannelMemberAddContext struct {
 	*EventHandlerCommonContext
 	Extra struct {
-		MentionRoles []int64  `json:"mention_roles"`
-		MentionHere  bool     `json:"mention_here"`
-		Author       User     `json:"author"`
-		Quote        *Quote   `json:"quote"`
-		GuildID      string   `json:"guild_id"`
-		ChannelName  string   `json:"channel_name"`
-		Mention      []string `json:"mention"`
-		MentionAll   bool     `json:"mention_all"`
+		ChannelID string         `json:"channel_id"`
+		JoinedAt  MilliTimeStamp `json:"joined_at"`
+		UserID    string         `json:"user_id"`
 	}
 }
 
-func (ctx *TextMessageContext) GetExtra() interface{} {
+func (ctx *GuildChannelMemberAddContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *TextMessageContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *GuildChannelMemberAddContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type EmojiRemovedEventHandler func(*EmojiRemovedContext)
+type GuildMemberAddEventHandler func(*GuildMemberAddContext)
 
-func (eh EmojiRemovedEventHandler) Type() string {
-	return "removed_emoji"
+func (eh GuildMemberAddEventHandler) Type() string {
+	return "joined_guild"
 }
-func (eh EmojiRemovedEventHandler) New() EventContext {
-	return &EmojiRemovedContext{}
+func (eh GuildMemberAddEventHandler) New() EventContext {
+	return &GuildMemberAddContext{}
 }
-func (eh EmojiRemovedEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*EmojiRemovedContext); ok {
+func (eh GuildMemberAddEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*GuildMemberAddContext); ok {
 		eh(t)
 	}
 }
 
-type EmojiRemovedContext struct {
+type GuildMemberAddContext struct {
 	*EventHandlerCommonContext
-	Extra EventEmojiItem
+	Extra struct {
+		JoinedAt MilliTimeStamp `json:"joined_at"`
+		UserID   string         `json:"user_id"`
+	}
 }
 
-func (ctx *EmojiRemovedContext) GetExtra() interface{} {
+func (ctx *GuildMemberAddContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *EmojiRemovedContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *GuildMemberAddContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type ReactionDeleteEventHandler func(*ReactionDeleteContext)
+type MessageButtonClickEventHandler func(*MessageButtonClickContext)
 
-func (eh ReactionDeleteEventHandler) Type() string {
-	return "deleted_reaction"
+func (eh MessageButtonClickEventHandler) Type() string {
+	return "message_btn_click"
 }
-func (eh ReactionDeleteEventHandler) New() EventContext {
-	return &ReactionDeleteContext{}
+func (eh MessageButtonClickEventHandler) New() EventContext {
+	return &MessageButtonClickContext{}
 }
-func (eh ReactionDeleteEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*ReactionDeleteContext); ok {
+func (eh MessageButtonClickEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*MessageButtonClickContext); ok {
 		eh(t)
 	}
 }
 
-type ReactionDeleteContext struct {
+type MessageButtonClickContext struct {
 	*EventHandlerCommonContext
-	Extra EventReactionItem
+	Extra struct {
+		GuildID  string `json:"guild_id"`
+		MsgID    string `json:"msg_id"`
+		TargetID string `json:"target_id"`
+		UserID   string `json:"user_id"`
+		UserInfo User   `json:"user_info"`
+		Value    string `json:"value"`
+	}
 }
 
-func (ctx *ReactionDeleteContext) GetExtra() interface{} {
+func (ctx *MessageButtonClickContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *ReactionDeleteContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *MessageButtonClickContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type MessageUpdateEventHandler func(*MessageUpdateContext)
+// Message returns the handle of the message of the event.
+func (ctx *MessageButtonClickContext) Message() *Message {
+	return ctx.eventMessage(ctx.Extra.MsgID, ctx.Extra.TargetID, ctx.Extra.UserID, ctx.Extra.GuildID == "")
+}
 
-func (eh MessageUpdateEventHandler) Type() string {
-	return "updated_message"
+// Reply sends a message quoting the message of the event, to where the message is.
+func (ctx *MessageButtonClickContext) Reply(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, options)
 }
-func (eh MessageUpdateEventHandler) New() EventContext {
-	return &MessageUpdateContext{}
+
+// ReplyCard sends a card message quoting the message of the event, to where the message is.
+func (ctx *MessageButtonClickContext) ReplyCard(card CardMessage, options ...SendOption) (*Message, error) {
+	return ctx.Message().replyCard(card, options)
 }
-func (eh MessageUpdateEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*MessageUpdateContext); ok {
-		eh(t)
-	}
+
+// ReplyTemp sends a temporary message only visible to the user of the event, which is only supported in channels.
+func (ctx *MessageButtonClickContext) ReplyTemp(content string, options ...SendOption) (*Message, error) {
+	return ctx.Message().reply(content, append(options, SendWithTempTarget(ctx.Extra.UserID)))
 }
 
-type MessageUpdateContext struct {
-	*EventHandlerCommonContext
-	Extra struct {
-		Content      string         `json:"content"`
-		ChannelID    string         `json:"channel_id"`
-		Mention      []string       `json:"mention"`
-		MentionAll   bool           `json:"mention_all"`
-		MentionHere  bool           `json:"mention_here"`
-		MentionRoles []int64        `json:"mention_roles"`
-		UpdatedAt    MilliTimeStamp `json:"updated_at"`
-		MsgID        string         `json:"msg_id"`
-	}
+// React adds the emoji reaction to the message of the event.
+func (ctx *MessageButtonClickContext) React(emoji string) error {
+	return ctx.Message().React(emoji)
 }
 
-func (ctx *MessageUpdateContext) GetExtra() interface{} {
-	return &ctx.Extra
+// Delete deletes the message of the event.
+func (ctx *MessageButtonClickContext) Delete() error {
+	return ctx.Message().Delete()
 }
-func (ctx *MessageUpdateContext) GetCommon() *EventHandlerCommonContext {
-	if ctx.EventHandlerCommonContext == nil {
-		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
-	}
-	return ctx.EventHandlerCommonContext
+
+// Edit replaces the content of the message of the event, which must be sent by the bot.
+func (ctx *MessageButtonClickContext) Edit(content string, options ...SendOption) error {
+	return ctx.Message().Edit(content, options...)
 }
 
-type ChannelAddEventHandler func(*ChannelAddContext)
+type MessagePinEventHandler func(*MessagePinContext)
 
-func (eh ChannelAddEventHandler) Type() string {
-	return "added_channel"
+func (eh MessagePinEventHandler) Type() string {
+	return "pinned_message"
 }
-func (eh ChannelAddEventHandler) New() EventContext {
-	return &ChannelAddContext{}
+func (eh MessagePinEventHandler) New() EventContext {
+	return &MessagePinContext{}
 }
-func (eh ChannelAddEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*ChannelAddContext); ok {
+func (eh MessagePinEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*MessagePinContext); ok {
 		eh(t)
 	}
 }
 
-type ChannelAddContext struct {
+type MessagePinContext struct {
 	*EventHandlerCommonContext
-	Extra Channel
+	Extra EventPinMessageItem
 }
 
-func (ctx *ChannelAddContext) GetExtra() interface{} {
+func (ctx *MessagePinContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *ChannelAddContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *MessagePinContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type BotJoinEventHandler func(*BotJoinContext)
+type DirectMessageReactionAddEventHandler func(*DirectMessageReactionAddContext)
 
-func (eh BotJoinEventHandler) Type() string {
-	return "self_joined_guild"
+func (eh DirectMessageReactionAddEventHandler) Type() string {
+	return "private_added_reaction"
 }
-func (eh BotJoinEventHandler) New() EventContext {
-	return &BotJoinContext{}
+func (eh DirectMessageReactionAddEventHandler) New() EventContext {
+	return &DirectMessageReactionAddContext{}
 }
-func (eh BotJoinEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*BotJoinContext); ok {
+func (eh DirectMessageReactionAddEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*DirectMessageReactionAddContext); ok {
 		eh(t)
 	}
 }
 
-type BotJoinContext struct {
+type DirectMessageReactionAddContext struct {
 	*EventHandlerCommonContext
-	Extra struct {
-		GuildID string `json:"guild_id"`
-	}
+	Extra EventDirectChatReactionItem
 }
 
-func (ctx *BotJoinContext) GetExtra() interface{} {
+func (ctx *DirectMessageReactionAddContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *BotJoinContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *DirectMessageReactionAddContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
 	return ctx.EventHandlerCommonContext
 }
 
-type GuildUpdateEventHandler func(*GuildUpdateContext)
+type DirectMessageReactionDeleteEventHandler func(*DirectMessageReactionDeleteContext)
 
-func (eh GuildUpdateEventHandler) Type() string {
-	return "updated_guild"
+func (eh DirectMessageReactionDeleteEventHandler) Type() string {
+	return "private_deleted_reaction"
 }
-func (eh GuildUpdateEventHandler) New() EventContext {
-	return &GuildUpdateContext{}
+func (eh DirectMessageReactionDeleteEventHandler) New() EventContext {
+	return &DirectMessageReactionDeleteContext{}
 }
-func (eh GuildUpdateEventHandler) Handle(i EventContext) {
-	if t, ok := i.(*GuildUpdateContext); ok {
+func (eh DirectMessageReactionDeleteEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*DirectMessageReactionDeleteContext); ok {
 		eh(t)
 	}
 }
 
-type GuildUpdateContext struct {
+type DirectMessageReactionDeleteContext struct {
 	*EventHandlerCommonContext
-	Extra EventGuild
+	Extra EventDirectChatReactionItem
 }
 
-func (ctx *GuildUpdateContext) GetExtra() interface{} {
+func (ctx *DirectMessageReactionDeleteContext) GetExtra() interface{} {
 	return &ctx.Extra
 }
-func (ctx *GuildUpdateContext) GetCommon() *EventHandlerCommonContext {
+func (ctx *DirectMessageReactionDeleteContext) GetCommon() *EventHandlerCommonContext {
+	if ctx.EventHandlerCommonContext == nil {
+		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
+	}
+	return ctx.EventHandlerCommonContext
+}
+
+type EmojiRemovedEventHandler func(*EmojiRemovedContext)
+
+func (eh EmojiRemovedEventHandler) Type() string {
+	return "removed_emoji"
+}
+func (eh EmojiRemovedEventHandler) New() EventContext {
+	return &EmojiRemovedContext{}
+}
+func (eh EmojiRemovedEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*EmojiRemovedContext); ok {
+		eh(t)
+	}
+}
+
+type EmojiRemovedContext struct {
+	*EventHandlerCommonContext
+	Extra EventEmojiItem
+}
+
+func (ctx *EmojiRemovedContext) GetExtra() interface{} {
+	return &ctx.Extra
+}
+func (ctx *EmojiRemovedContext) GetCommon() *EventHandlerCommonContext {
+	if ctx.EventHandlerCommonContext == nil {
+		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
+	}
+	return ctx.EventHandlerCommonContext
+}
+
+type BotExitEventHandler func(*BotExitContext)
+
+func (eh BotExitEventHandler) Type() string {
+	return "self_exited_guild"
+}
+func (eh BotExitEventHandler) New() EventContext {
+	return &BotExitContext{}
+}
+func (eh BotExitEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*BotExitContext); ok {
+		eh(t)
+	}
+}
+
+type BotExitContext struct {
+	*EventHandlerCommonContext
+	Extra struct {
+		GuildID string `json:"guild_id"`
+	}
+}
+
+func (ctx *BotExitContext) GetExtra() interface{} {
+	return &ctx.Extra
+}
+func (ctx *BotExitContext) GetCommon() *EventHandlerCommonContext {
+	if ctx.EventHandlerCommonContext == nil {
+		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
+	}
+	return ctx.EventHandlerCommonContext
+}
+
+type BotJoinEventHandler func(*BotJoinContext)
+
+func (eh BotJoinEventHandler) Type() string {
+	return "self_joined_guild"
+}
+func (eh BotJoinEventHandler) New() EventContext {
+	return &BotJoinContext{}
+}
+func (eh BotJoinEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*BotJoinContext); ok {
+		eh(t)
+	}
+}
+
+type BotJoinContext struct {
+	*EventHandlerCommonContext
+	Extra struct {
+		GuildID string `json:"guild_id"`
+	}
+}
+
+func (ctx *BotJoinContext) GetExtra() interface{} {
+	return &ctx.Extra
+}
+func (ctx *BotJoinContext) GetCommon() *EventHandlerCommonContext {
 	if ctx.EventHandlerCommonContext == nil {
 		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
 	}
@@ -1311,6 +1426,199 @@ func (ctx *MessageUnpinContext) GetCommon() *EventHandlerCommonContext {
 	return ctx.EventHandlerCommonContext
 }
 
+type ChannelUpdateEventHandler func(*ChannelUpdateContext)
+
+func (eh ChannelUpdateEventHandler) Type() string {
+	return "updated_channel"
+}
+func (eh ChannelUpdateEventHandler) New() EventContext {
+	return &ChannelUpdateContext{}
+}
+func (eh ChannelUpdateEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*ChannelUpdateContext); ok {
+		eh(t)
+	}
+}
+
+type ChannelUpdateContext struct {
+	*EventHandlerCommonContext
+	Extra Channel
+}
+
+func (ctx *ChannelUpdateContext) GetExtra() interface{} {
+	return &ctx.Extra
+}
+func (ctx *ChannelUpdateContext) GetCommon() *EventHandlerCommonContext {
+	if ctx.EventHandlerCommonContext == nil {
+		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
+	}
+	return ctx.EventHandlerCommonContext
+}
+
+type EmojiUpdatedEventHandler func(*EmojiUpdatedContext)
+
+func (eh EmojiUpdatedEventHandler) Type() string {
+	return "updated_emoji"
+}
+func (eh EmojiUpdatedEventHandler) New() EventContext {
+	return &EmojiUpdatedContext{}
+}
+func (eh EmojiUpdatedEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*EmojiUpdatedContext); ok {
+		eh(t)
+	}
+}
+
+type EmojiUpdatedContext struct {
+	*EventHandlerCommonContext
+	Extra EventEmojiItem
+}
+
+func (ctx *EmojiUpdatedContext) GetExtra() interface{} {
+	return &ctx.Extra
+}
+func (ctx *EmojiUpdatedContext) GetCommon() *EventHandlerCommonContext {
+	if ctx.EventHandlerCommonContext == nil {
+		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
+	}
+	return ctx.EventHandlerCommonContext
+}
+
+type GuildUpdateEventHandler func(*GuildUpdateContext)
+
+func (eh GuildUpdateEventHandler) Type() string {
+	return "updated_guild"
+}
+func (eh GuildUpdateEventHandler) New() EventContext {
+	return &GuildUpdateContext{}
+}
+func (eh GuildUpdateEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*GuildUpdateContext); ok {
+		eh(t)
+	}
+}
+
+type GuildUpdateContext struct {
+	*EventHandlerCommonContext
+	Extra EventGuild
+}
+
+func (ctx *GuildUpdateContext) GetExtra() interface{} {
+	return &ctx.Extra
+}
+func (ctx *GuildUpdateContext) GetCommon() *EventHandlerCommonContext {
+	if ctx.EventHandlerCommonContext == nil {
+		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
+	}
+	return ctx.EventHandlerCommonContext
+}
+
+type GuildMemberUpdateEventHandler func(*GuildMemberUpdateContext)
+
+func (eh GuildMemberUpdateEventHandler) Type() string {
+	return "updated_guild_member"
+}
+func (eh GuildMemberUpdateEventHandler) New() EventContext {
+	return &GuildMemberUpdateContext{}
+}
+func (eh GuildMemberUpdateEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*GuildMemberUpdateContext); ok {
+		eh(t)
+	}
+}
+
+type GuildMemberUpdateContext struct {
+	*EventHandlerCommonContext
+	Extra struct {
+		Nickname string `json:"nickname"`
+		UserID   string `json:"user_id"`
+	}
+}
+
+func (ctx *GuildMemberUpdateContext) GetExtra() interface{} {
+	return &ctx.Extra
+}
+func (ctx *GuildMemberUpdateContext) GetCommon() *EventHandlerCommonContext {
+	if ctx.EventHandlerCommonContext == nil {
+		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
+	}
+	return ctx.EventHandlerCommonContext
+}
+
+type MessageUpdateEventHandler func(*MessageUpdateContext)
+
+func (eh MessageUpdateEventHandler) Type() string {
+	return "updated_message"
+}
+func (eh MessageUpdateEventHandler) New() EventContext {
+	return &MessageUpdateContext{}
+}
+func (eh MessageUpdateEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*MessageUpdateContext); ok {
+		eh(t)
+	}
+}
+
+type MessageUpdateContext struct {
+	*EventHandlerCommonContext
+	Extra struct {
+		ChannelID    string         `json:"channel_id"`
+		Content      string         `json:"content"`
+		Mention      []string       `json:"mention"`
+		MentionAll   bool           `json:"mention_all"`
+		MentionHere  bool           `json:"mention_here"`
+		MentionRoles []int64        `json:"mention_roles"`
+		MsgID        string         `json:"msg_id"`
+		UpdatedAt    MilliTimeStamp `json:"updated_at"`
+	}
+}
+
+func (ctx *MessageUpdateContext) GetExtra() interface{} {
+	return &ctx.Extra
+}
+func (ctx *MessageUpdateContext) GetCommon() *EventHandlerCommonContext {
+	if ctx.EventHandlerCommonContext == nil {
+		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
+	}
+	return ctx.EventHandlerCommonContext
+}
+
+type DirectMessageUpdateEventHandler func(*DirectMessageUpdateContext)
+
+func (eh DirectMessageUpdateEventHandler) Type() string {
+	return "updated_private_message"
+}
+func (eh DirectMessageUpdateEventHandler) New() EventContext {
+	return &DirectMessageUpdateContext{}
+}
+func (eh DirectMessageUpdateEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*DirectMessageUpdateContext); ok {
+		eh(t)
+	}
+}
+
+type DirectMessageUpdateContext struct {
+	*EventHandlerCommonContext
+	Extra struct {
+		AuthorID  string         `json:"author_id"`
+		ChatCode  string         `json:"chat_code"`
+		Content   string         `json:"content"`
+		MsgID     string         `json:"msg_id"`
+		TargetID  string         `json:"target_id"`
+		UpdatedAt MilliTimeStamp `json:"updated_at"`
+	}
+}
+
+func (ctx *DirectMessageUpdateContext) GetExtra() interface{} {
+	return &ctx.Extra
+}
+func (ctx *DirectMessageUpdateContext) GetCommon() *EventHandlerCommonContext {
+	if ctx.EventHandlerCommonContext == nil {
+		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
+	}
+	return ctx.EventHandlerCommonContext
+}
+
 type GuildRoleUpdateEventHandler func(*GuildRoleUpdateContext)
 
 func (eh GuildRoleUpdateEventHandler) Type() string {
@@ -1339,133 +1647,166 @@ func (ctx *GuildRoleUpdateContext) GetCommon() *EventHandlerCommonContext {
 	}
 	return ctx.EventHandlerCommonContext
 }
+
+type UserUpdateEventHandler func(*UserUpdateContext)
+
+func (eh UserUpdateEventHandler) Type() string {
+	return "user_updated"
+}
+func (eh UserUpdateEventHandler) New() EventContext {
+	return &UserUpdateContext{}
+}
+func (eh UserUpdateEventHandler) Handle(i EventContext) {
+	if t, ok := i.(*UserUpdateContext); ok {
+		eh(t)
+	}
+}
+
+type UserUpdateContext struct {
+	*EventHandlerCommonContext
+	Extra struct {
+		Avatar   string `json:"avatar"`
+		UserID   string `json:"user_id"`
+		Username string `json:"username"`
+	}
+}
+
+func (ctx *UserUpdateContext) GetExtra() interface{} {
+	return &ctx.Extra
+}
+func (ctx *UserUpdateContext) GetCommon() *EventHandlerCommonContext {
+	if ctx.EventHandlerCommonContext == nil {
+		ctx.EventHandlerCommonContext = new(EventHandlerCommonContext)
+	}
+	return ctx.EventHandlerCommonContext
+}
 func init() {
-	registerEventHandler(GuildMemberUpdateEventHandler(nil))
-	registerEventHandler(GuildChannelMemberAddEventHandler(nil))
-	registerEventHandler(UserUpdateEventHandler(nil))
-	registerEventHandler(FileMessageEventHandler(nil))
-	registerEventHandler(MessageDeleteEventHandler(nil))
-	registerEventHandler(ChannelDeleteEventHandler(nil))
-	registerEventHandler(MessagePinEventHandler(nil))
-	registerEventHandler(BotExitEventHandler(nil))
-	registerEventHandler(VideoMessageEventHandler(nil))
+	registerEventHandler(TextMessageEventHandler(nil))
 	registerEventHandler(EventCardMessageEventHandler(nil))
+	registerEventHandler(ImageMessageEventHandler(nil))
+	registerEventHandler(VideoMessageEventHandler(nil))
+	registerEventHandler(FileMessageEventHandler(nil))
+	registerEventHandler(AudioMessageEventHandler(nil))
+	registerEventHandler(KmarkdownMessageEventHandler(nil))
+	registerEventHandler(BlockListAddEventHandler(nil))
+	registerEventHandler(ChannelAddEventHandler(nil))
+	registerEventHandler(EmojiAddedEventHandler(nil))
 	registerEventHandler(ReactionAddEventHandler(nil))
-	registerEventHandler(GuildMemberAddEventHandler(nil))
-	registerEventHandler(GuildChannelMemberDeleteEventHandler(nil))
-	registerEventHandler(GuildMemberDeleteEventHandler(nil))
 	registerEventHandler(GuildRoleAddEventHandler(nil))
-	registerEventHandler(GuildRoleDeleteEventHandler(nil))
-	registerEventHandler(MessageButtonClickEventHandler(nil))
-	registerEventHandler(EmojiAddedEventHandler(nil))
+	registerEventHandler(BlockListDeleteEventHandler(nil))
+	registerEventHandler(ChannelDeleteEventHandler(nil))
 	registerEventHandler(GuildDeleteEventHandler(nil))
-	registerEventHandler(ChannelUpdateEventHandler(nil))
+	registerEventHandler(MessageDeleteEventHandler(nil))
 	registerEventHandler(DirectMessageDeleteEventHandler(nil))
-	registerEventHandler(DirectMessageReactionAddEventHandler(nil))
+	registerEventHandler(ReactionDeleteEventHandler(nil))
+	registerEventHandler(GuildRoleDeleteEventHandler(nil))
+	registerEventHandler(GuildChannelMemberDeleteEventHandler(nil))
+	registerEventHandler(GuildMemberDeleteEventHandler(nil))
+	registerEventHandler(GuildMemberOfflineEventHandler(nil))
 	registerEventHandler(GuildMemberOnlineEventHandler(nil))
-	registerEventHandler(ImageMessageEventHandler(nil))
-	registerEventHandler(AudioMessageEventHandler(nil))
-	registerEventHandler(BlockListAddEventHandler(nil))
-	registerEventHandler(BlockListDeleteEventHandler(nil))
-	registerEventHandler(DirectMessageUpdateEventHandler(nil))
-	registerEventHandler(KmarkdownMessageEventHandler(nil))
+	registerEventHandler(GuildChannelMemberAddEventHandler(nil))
+	registerEventHandler(GuildMemberAddEventHandler(nil))
+	registerEventHandler(MessageButtonClickEventHandler(nil))
+	registerEventHandler(MessagePinEventHandler(nil))
+	registerEventHandler(DirectMessageReactionAddEventHandler(nil))
 	registerEventHandler(DirectMessageReactionDeleteEventHandler(nil))
-	registerEventHandler(GuildMemberOfflineEventHandler(nil))
-	registerEventHandler(EmojiUpdatedEventHandler(nil))
-	registerEventHandler(TextMessageEventHandler(nil))
 	registerEventHandler(EmojiRemovedEventHandler(nil))
-	registerEventHandler(ReactionDeleteEventHandler(nil))
-	registerEventHandler(MessageUpdateEventHandler(nil))
-	registerEventHandler(ChannelAddEventHandler(nil))
+	registerEventHandler(BotExitEventHandler(nil))
 	registerEventHandler(BotJoinEventHandler(nil))
-	registerEventHandler(GuildUpdateEventHandler(nil))
 	registerEventHandler(MessageUnpinEventHandler(nil))
+	registerEventHandler(ChannelUpdateEventHandler(nil))
+	registerEventHandler(EmojiUpdatedEventHandler(nil))
+	registerEventHandler(GuildUpdateEventHandler(nil))
+	registerEventHandler(GuildMemberUpdateEventHandler(nil))
+	registerEventHandler(MessageUpdateEventHandler(nil))
+	registerEventHandler(DirectMessageUpdateEventHandler(nil))
 	registerEventHandler(GuildRoleUpdateEventHandler(nil))
+	registerEventHandler(UserUpdateEventHandler(nil))
 }
 func handlerForInterface(i interface{}) EventHandler {
 	switch v := i.(type) {
-	case func(*GuildMemberUpdateContext):
-		return GuildMemberUpdateEventHandler(v)
-	case func(*GuildChannelMemberAddContext):
-		return GuildChannelMemberAddEventHandler(v)
-	case func(*UserUpdateContext):
-		return UserUpdateEventHandler(v)
-	case func(*FileMessageContext):
-		return FileMessageEventHandler(v)
-	case func(*MessageDeleteContext):
-		return MessageDeleteEventHandler(v)
-	case func(*ChannelDeleteContext):
-		return ChannelDeleteEventHandler(v)
-	case func(*MessagePinContext):
-		return MessagePinEventHandler(v)
-	case func(*BotExitContext):
-		return BotExitEventHandler(v)
-	case func(*VideoMessageContext):
-		return VideoMessageEventHandler(v)
+	case func(*TextMessageContext):
+		return TextMessageEventHandler(v)
 	case func(*EventCardMessageContext):
 		return EventCardMessageEventHandler(v)
+	case func(*ImageMessageContext):
+		return ImageMessageEventHandler(v)
+	case func(*VideoMessageContext):
+		return VideoMessageEventHandler(v)
+	case func(*FileMessageContext):
+		return FileMessageEventHandler(v)
+	case func(*AudioMessageContext):
+		return AudioMessageEventHandler(v)
+	case func(*KmarkdownMessageContext):
+		return KmarkdownMessageEventHandler(v)
+	case func(*BlockListAddContext):
+		return BlockListAddEventHandler(v)
+	case func(*ChannelAddContext):
+		return ChannelAddEventHandler(v)
+	case func(*EmojiAddedContext):
+		return EmojiAddedEventHandler(v)
 	case func(*ReactionAddContext):
 		return ReactionAddEventHandler(v)
-	case func(*GuildMemberAddContext):
-		return GuildMemberAddEventHandler(v)
-	case func(*GuildChannelMemberDeleteContext):
-		return GuildChannelMemberDeleteEventHandler(v)
-	case func(*GuildMemberDeleteContext):
-		return GuildMemberDeleteEventHandler(v)
 	case func(*GuildRoleAddContext):
 		return GuildRoleAddEventHandler(v)
-	case func(*GuildRoleDeleteContext):
-		return GuildRoleDeleteEventHandler(v)
-	case func(*MessageButtonClickContext):
-		return MessageButtonClickEventHandler(v)
-	case func(*EmojiAddedContext):
-		return EmojiAddedEventHandler(v)
+	case func(*BlockListDeleteContext):
+		return BlockListDeleteEventHandler(v)
+	case func(*ChannelDeleteContext):
+		return ChannelDeleteEventHandler(v)
 	case func(*GuildDeleteContext):
 		return GuildDeleteEventHandler(v)
-	case func(*ChannelUpdateContext):
-		return ChannelUpdateEventHandler(v)
+	case func(*MessageDeleteContext):
+		return MessageDeleteEventHandler(v)
 	case func(*DirectMessageDeleteContext):
 		return DirectMessageDeleteEventHandler(v)
-	case func(*DirectMessageReactionAddContext):
-		return DirectMessageReactionAddEventHandler(v)
+	case func(*ReactionDeleteContext):
+		return ReactionDeleteEventHandler(v)
+	case func(*GuildRoleDeleteContext):
+		return GuildRoleDeleteEventHandler(v)
+	case func(*GuildChannelMemberDeleteContext):
+		return GuildChannelMemberDeleteEventHandler(v)
+	case func(*GuildMemberDeleteContext):
+		return GuildMemberDeleteEventHandler(v)
+	case func(*GuildMemberOfflineContext):
+		return GuildMemberOfflineEventHandler(v)
 	case func(*GuildMemberOnlineContext):
 		return GuildMemberOnlineEventHandler(v)
-	case func(*ImageMessageContext):
-		return ImageMessageEventHandler(v)
-	case func(*AudioMessageContext):
-		return AudioMessageEventHandler(v)
-	case func(*BlockListAddContext):
-		return BlockListAddEventHandler(v)
-	case func(*BlockListDeleteContext):
-		return BlockListDeleteEventHandler(v)
-	case func(*DirectMessageUpdateContext):
-		return DirectMessageUpdateEventHandler(v)
-	case func(*KmarkdownMessageContext):
-		return KmarkdownMessageEventHandler(v)
+	case func(*GuildChannelMemberAddContext):
+		return GuildChannelMemberAddEventHandler(v)
+	case func(*GuildMemberAddContext):
+		return GuildMemberAddEventHandler(v)
+	case func(*MessageButtonClickContext):
+		return MessageButtonClickEventHandler(v)
+	case func(*MessagePinContext):
+		return MessagePinEventHandler(v)
+	case func(*DirectMessageReactionAddContext):
+		return DirectMessageReactionAddEventHandler(v)
 	case func(*DirectMessageReactionDeleteContext):
 		return DirectMessageReactionDeleteEventHandler(v)
-	case func(*GuildMemberOfflineContext):
-		return GuildMemberOfflineEventHandler(v)
-	case func(*EmojiUpdatedContext):
-		return EmojiUpdatedEventHandler(v)
-	case func(*TextMessageContext):
-		return TextMessageEventHandler(v)
 	case func(*EmojiRemovedContext):
 		return EmojiRemovedEventHandler(v)
-	case func(*ReactionDeleteContext):
-		return ReactionDeleteEventHandler(v)
-	case func(*MessageUpdateContext):
-		return MessageUpdateEventHandler(v)
-	case func(*ChannelAddContext):
-		return ChannelAddEventHandler(v)
+	case func(*BotExitContext):
+		return BotExitEventHandler(v)
 	case func(*BotJoinContext):
 		return BotJoinEventHandler(v)
-	case func(*GuildUpdateContext):
-		return GuildUpdateEventHandler(v)
 	case func(*MessageUnpinContext):
 		return MessageUnpinEventHandler(v)
+	case func(*ChannelUpdateContext):
+		return ChannelUpdateEventHandler(v)
+	case func(*EmojiUpdatedContext):
+		return EmojiUpdatedEventHandler(v)
+	case func(*GuildUpdateContext):
+		return GuildUpdateEventHandler(v)
+	case func(*GuildMemberUpdateContext):
+		return GuildMemberUpdateEventHandler(v)
+	case func(*MessageUpdateContext):
+		return MessageUpdateEventHandler(v)
+	case func(*DirectMessageUpdateContext):
+		return DirectMessageUpdateEventHandler(v)
 	case func(*GuildRoleUpdateContext):
 		return GuildRoleUpdateEventHandler(v)
+	case func(*UserUpdateContext):
+		return UserUpdateEventHandler(v)
 	}
 	return nil
 }
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/lonelyevil/kook"
 	"github.com/lonelyevil/kook/log_adapter/plog"
@@ -27,7 +29,9 @@ func main() {
 	<-sc
 
 	// Cleanly close down the Kook session.
-	s.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Shutdown(ctx)
 }
 
 func messageHan(ctx *kook.KmarkdownMessageContext) {
@@ -35,14 +39,7 @@ func messageHan(ctx *kook.KmarkdownMessageContext) {
 		return
 	}
 	if strings.Contains(ctx.Common.Content, "ping") {
-		ctx.Session.MessageCreate(&kook.MessageCreate{
-			MessageCreateBase: kook.MessageCreateBase{
-				TargetID: ctx.Common.TargetID,
-				Content:  "pong",
-				Quote:    ctx.Common.MsgID,
-				Type:     kook.MessageTypeKMarkdown,
-			},
-		})
+		ctx.Reply("pong", kook.SendWithKMarkdown())
 	}
 
 }
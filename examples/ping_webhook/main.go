@@ -28,14 +28,7 @@ func messageHan(ctx *kook.KmarkdownMessageContext) {
 		return
 	}
 	if strings.Contains(ctx.Common.Content, "ping") {
-		ctx.Session.MessageCreate(&kook.MessageCreate{
-			MessageCreateBase: kook.MessageCreateBase{
-				TargetID: ctx.Common.TargetID,
-				Content:  "pong",
-				Quote:    ctx.Common.MsgID,
-				Type:     kook.MessageTypeKMarkdown,
-			},
-		})
+		ctx.Reply("pong", kook.SendWithKMarkdown())
 	}
 
 }
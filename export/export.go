@@ -0,0 +1,229 @@
+// Package export dumps the message history of kook channels into archives,
+// as JSON lines, a self-contained HTML transcript or Markdown.
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lonelyevil/kook"
+)
+
+// Format is the type for archive formats.
+type Format int
+
+// These are the supported archive formats.
+const (
+	// FormatJSONL writes a message as JSON in every line.
+	FormatJSONL Format = iota
+	// FormatHTML writes a self-contained HTML transcript.
+	FormatHTML
+	// FormatMarkdown writes a Markdown transcript.
+	FormatMarkdown
+)
+
+// ErrUnknownFormat is the error when the format is not supported.
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Option is the type for optional arguments of Channel.
+type Option func(*config)
+
+type config struct {
+	from          time.Time
+	until         time.Time
+	pageSize      int
+	attachmentDir string
+	client        *http.Client
+	location      *time.Location
+	progress      func(messages int)
+}
+
+// WithTimeRange exports only messages sent between from and until. A zero time leaves the side open.
+func WithTimeRange(from, until time.Time) Option {
+	return func(c *config) {
+		c.from = from
+		c.until = until
+	}
+}
+
+// WithPageSize sets the number of messages fetched in a request.
+func WithPageSize(size int) Option {
+	return func(c *config) {
+		c.pageSize = size
+	}
+}
+
+// WithAttachments downloads attachments into dir, and the archive refers to the downloaded files
+// by paths relative to dir.
+func WithAttachments(dir string) Option {
+	return func(c *config) {
+		c.attachmentDir = dir
+	}
+}
+
+// WithHTTPClient sets the http client downloading attachments, http.DefaultClient by default.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		c.client = client
+	}
+}
+
+// WithLocation sets the time zone of times in HTML and Markdown, time.Local by default.
+func WithLocation(loc *time.Location) Option {
+	return func(c *config) {
+		c.location = loc
+	}
+}
+
+// WithProgress sets the function called with the number of exported messages after each message.
+func WithProgress(f func(messages int)) Option {
+	return func(c *config) {
+		c.progress = f
+	}
+}
+
+// Message is a message in the archive.
+type Message struct {
+	*kook.DetailedChannelMessage
+	// LocalAttachment is the path of the downloaded attachment relative to the attachment directory.
+	LocalAttachment string `json:"local_attachment,omitempty"`
+}
+
+// archiver writes messages of a format.
+type archiver interface {
+	begin(c *kook.Channel) error
+	message(m *Message) error
+	end() error
+}
+
+func newArchiver(f Format, w io.Writer, loc *time.Location) (archiver, error) {
+	switch f {
+	case FormatJSONL:
+		return newJSONLArchiver(w), nil
+	case FormatHTML:
+		return &htmlArchiver{w: w, loc: loc}, nil
+	case FormatMarkdown:
+		return &markdownArchiver{w: w, loc: loc}, nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+// Channel exports the message history of the channel to w in the format, from the oldest message to the newest.
+//
+// It returns the number of exported messages. As kook lists messages backward from the latest,
+// the history is walked back to the start before exporting.
+func Channel(ctx context.Context, s *kook.Session, channelID string, w io.Writer, f Format, options ...Option) (n int, err error) {
+	c := &config{
+		client:   http.DefaultClient,
+		location: time.Local,
+	}
+	for _, item := range options {
+		item(c)
+	}
+	a, err := newArchiver(f, w, c.location)
+	if err != nil {
+		return 0, err
+	}
+	ch, err := s.ChannelView(channelID)
+	if err != nil {
+		return 0, err
+	}
+	if c.attachmentDir != "" {
+		if err = os.MkdirAll(c.attachmentDir, 0755); err != nil {
+			return 0, err
+		}
+	}
+	if err = a.begin(ch); err != nil {
+		return 0, err
+	}
+	ho := []kook.HistoryOption{
+		kook.HistoryWithDirection(kook.HistoryForward),
+		kook.HistoryFromTime(c.from),
+		kook.HistoryUntil(c.until),
+	}
+	if c.pageSize > 0 {
+		ho = append(ho, kook.HistoryWithPageSize(c.pageSize))
+	}
+	it := s.MessageListAll(ctx, channelID, ho...)
+	for it.Next() {
+		m := &Message{DetailedChannelMessage: it.Item()}
+		if c.attachmentDir != "" && m.Attachments != nil && m.Attachments.URL != "" {
+			m.LocalAttachment, err = c.download(ctx, m.ID, m.Attachments)
+			if err != nil {
+				return n, fmt.Errorf("downloading attachment of message %s: %w", m.ID, err)
+			}
+		}
+		if err = a.message(m); err != nil {
+			return n, err
+		}
+		n++
+		if c.progress != nil {
+			c.progress(n)
+		}
+	}
+	if err = it.Err(); err != nil {
+		return n, err
+	}
+	return n, a.end()
+}
+
+// download saves the attachment as <message id>-<name> in the attachment directory.
+func (c *config) download(ctx context.Context, msgID string, a *kook.Attachment) (name string, err error) {
+	name = a.Name
+	if name == "" {
+		if u, err := url.Parse(a.URL); err == nil {
+			name = path.Base(u.Path)
+		}
+	}
+	name = msgID + "-" + sanitizeFileName(name)
+	req, err := http.NewRequestWithContext(ctx, "GET", a.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	file, err := os.Create(filepath.Join(c.attachmentDir, name))
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(file, resp.Body)
+	if err2 := file.Close(); err == nil {
+		err = err2
+	}
+	if err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		if r < 0x20 {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == ".." {
+		name = "attachment"
+	}
+	return name
+}
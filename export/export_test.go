@@ -0,0 +1,86 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lonelyevil/kook"
+)
+
+func testMessages() []*Message {
+	return []*Message{
+		{DetailedChannelMessage: &kook.DetailedChannelMessage{
+			ID:       "1",
+			Type:     kook.MessageTypeKMarkdown,
+			Author:   kook.User{Username: "alice", IdentifyNum: "0001"},
+			Content:  "hello <world>",
+			CreateAt: 1000,
+			Reactions: []kook.ReactionItem{
+				{Emoji: kook.EmojiItem{Name: "👍"}, Count: 2},
+			},
+		}},
+		{DetailedChannelMessage: &kook.DetailedChannelMessage{
+			ID:          "2",
+			Type:        kook.MessageTypeImage,
+			Author:      kook.User{Username: "bob"},
+			Attachments: &kook.Attachment{Type: "image", Name: "cat.png", URL: "https://example.com/cat.png"},
+			CreateAt:    2000,
+		}, LocalAttachment: "2-cat.png"},
+	}
+}
+
+func archive(t *testing.T, f Format) string {
+	buf := &bytes.Buffer{}
+	a, err := newArchiver(f, buf, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = a.begin(&kook.Channel{Name: "general", Topic: "chat"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range testMessages() {
+		if err = a.message(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = a.end(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestJSONL(t *testing.T) {
+	get := archive(t, FormatJSONL)
+	lines := strings.Split(strings.TrimSpace(get), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], `"content":"hello <world>"`) || !strings.Contains(lines[1], `"local_attachment":"2-cat.png"`) {
+		t.Error(get)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	get := archive(t, FormatMarkdown)
+	want := "# general\n\nchat\n\n### alice#0001 · 1970-01-01 00:00:01\n\nhello <world>\n\n*👍 × 2*\n\n### bob · 1970-01-01 00:00:02\n\n![cat.png](2-cat.png)\n\n"
+	if get != want {
+		t.Error(get)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	get := archive(t, FormatHTML)
+	for _, want := range []string{"<title>general</title>", "hello &lt;world&gt;", `<img src="2-cat.png" alt="cat.png">`, "👍 × 2", "</html>"} {
+		if !strings.Contains(get, want) {
+			t.Error(want, get)
+		}
+	}
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	if get := sanitizeFileName("../a/b:c"); get != ".._a_b_c" {
+		t.Error(get)
+	}
+	if get := sanitizeFileName(".."); get != "attachment" {
+		t.Error(get)
+	}
+}
@@ -0,0 +1,190 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/lonelyevil/kook"
+)
+
+type jsonlArchiver struct {
+	enc *json.Encoder
+}
+
+func newJSONLArchiver(w io.Writer) *jsonlArchiver {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &jsonlArchiver{enc: enc}
+}
+
+func (a *jsonlArchiver) begin(c *kook.Channel) error {
+	return nil
+}
+
+func (a *jsonlArchiver) message(m *Message) error {
+	return a.enc.Encode(m)
+}
+
+func (a *jsonlArchiver) end() error {
+	return nil
+}
+
+func authorName(u kook.User) string {
+	name := u.Nickname
+	if name == "" {
+		name = u.Username
+	}
+	if u.IdentifyNum != "" {
+		name += "#" + u.IdentifyNum
+	}
+	return name
+}
+
+func messageTime(m *kook.DetailedChannelMessage, loc *time.Location) string {
+	t := m.CreateAt
+	return t.ToTime().In(loc).Format("2006-01-02 15:04:05")
+}
+
+func attachmentRef(m *Message) string {
+	if m.LocalAttachment != "" {
+		return m.LocalAttachment
+	}
+	if m.Attachments != nil {
+		return m.Attachments.URL
+	}
+	return ""
+}
+
+func isImage(m *Message) bool {
+	return m.Attachments != nil && (m.Attachments.Type == "image" || m.Type == kook.MessageTypeImage)
+}
+
+func reactionsText(rs []kook.ReactionItem) string {
+	parts := make([]string, 0, len(rs))
+	for _, r := range rs {
+		parts = append(parts, fmt.Sprintf("%s × %d", r.Emoji.Name, r.Count))
+	}
+	return strings.Join(parts, "  ")
+}
+
+type markdownArchiver struct {
+	w   io.Writer
+	loc *time.Location
+	err error
+}
+
+func (a *markdownArchiver) printf(format string, args ...interface{}) {
+	if a.err == nil {
+		_, a.err = fmt.Fprintf(a.w, format, args...)
+	}
+}
+
+func (a *markdownArchiver) begin(c *kook.Channel) error {
+	a.printf("# %s\n\n", c.Name)
+	if c.Topic != "" {
+		a.printf("%s\n\n", c.Topic)
+	}
+	return a.err
+}
+
+func quoteMarkdown(s string) string {
+	return "> " + strings.ReplaceAll(s, "\n", "\n> ")
+}
+
+func (a *markdownArchiver) message(m *Message) error {
+	a.printf("### %s · %s\n\n", authorName(m.Author), messageTime(m.DetailedChannelMessage, a.loc))
+	if q := m.Quote; q != nil {
+		a.printf("%s\n\n", quoteMarkdown(authorName(q.Author)+": "+q.Content))
+	}
+	switch {
+	case m.Type == kook.MessageTypeCard:
+		a.printf("```json\n%s\n```\n\n", m.Content)
+	case m.Attachments != nil:
+		if isImage(m) {
+			a.printf("![%s](%s)\n\n", m.Attachments.Name, attachmentRef(m))
+		} else {
+			a.printf("[%s](%s)\n\n", m.Attachments.Name, attachmentRef(m))
+		}
+	default:
+		a.printf("%s\n\n", m.Content)
+	}
+	if len(m.Reactions) != 0 {
+		a.printf("*%s*\n\n", reactionsText(m.Reactions))
+	}
+	return a.err
+}
+
+func (a *markdownArchiver) end() error {
+	return a.err
+}
+
+var htmlTemplate = template.Must(template.New("export").Funcs(template.FuncMap{
+	"author":    authorName,
+	"time":      messageTime,
+	"ref":       attachmentRef,
+	"image":     isImage,
+	"reactions": reactionsText,
+	"card": func(t kook.MessageType) bool {
+		return t == kook.MessageTypeCard
+	},
+}).Parse(`{{define "begin"}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Name}}</title>
+<style>
+body{font-family:sans-serif;background:#f5f5f5;color:#222;margin:0 auto;max-width:960px;padding:16px}
+.message{background:#fff;border-radius:6px;margin:8px 0;padding:8px 12px}
+.author{font-weight:bold}
+.time{color:#888;font-size:.85em;margin-left:8px}
+.content{white-space:pre-wrap;word-break:break-word;margin-top:4px}
+.quote{border-left:3px solid #ccc;color:#666;margin:4px 0;padding-left:8px;white-space:pre-wrap}
+.reactions{color:#666;font-size:.9em;margin-top:4px}
+img{max-width:100%}
+</style>
+</head>
+<body>
+<h1>{{.Name}}</h1>
+{{if .Topic}}<p>{{.Topic}}</p>
+{{end}}{{end}}{{define "message"}}<div class="message" id="{{.ID}}">
+<span class="author">{{author .Author}}</span><span class="time">{{time .DetailedChannelMessage .Loc}}</span>
+{{with .Quote}}<div class="quote">{{author .Author}}: {{.Content}}</div>
+{{end}}{{if card .Type}}<details><summary>card message</summary><pre class="content">{{.Content}}</pre></details>
+{{else if .Attachments}}{{if image .Message}}<div class="content"><img src="{{ref .Message}}" alt="{{.Attachments.Name}}"></div>
+{{else}}<div class="content"><a href="{{ref .Message}}">{{.Attachments.Name}}</a></div>
+{{end}}{{else}}<div class="content">{{.Content}}</div>
+{{end}}{{if .Reactions}}<div class="reactions">{{reactions .Reactions}}</div>
+{{end}}</div>
+{{end}}{{define "end"}}</body>
+</html>
+{{end}}`))
+
+type htmlArchiver struct {
+	w   io.Writer
+	loc *time.Location
+	bw  *bufio.Writer
+}
+
+func (a *htmlArchiver) begin(c *kook.Channel) error {
+	a.bw = bufio.NewWriter(a.w)
+	return htmlTemplate.ExecuteTemplate(a.bw, "begin", c)
+}
+
+func (a *htmlArchiver) message(m *Message) error {
+	return htmlTemplate.ExecuteTemplate(a.bw, "message", struct {
+		*Message
+		Loc *time.Location
+	}{m, a.loc})
+}
+
+func (a *htmlArchiver) end() error {
+	if err := htmlTemplate.ExecuteTemplate(a.bw, "end", nil); err != nil {
+		return err
+	}
+	return a.bw.Flush()
+}
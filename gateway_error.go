@@ -0,0 +1,62 @@
+package kook
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnexpectedSignal is the error when the gateway sends another signal instead of hello on connecting.
+var ErrUnexpectedSignal = errors.New("unexpected signal")
+
+// Error makes EventStatusCode usable as an errors.Is target, e.g. errors.Is(err, EventStatusInvalidToken).
+func (c EventStatusCode) Error() string {
+	switch c {
+	case EventStatusOk:
+		return "ok"
+	case EventStatusMissingArgument:
+		return "missing argument"
+	case EventStatusInvalidToken:
+		return "invalid token"
+	case EventStatusTokenAuthFailed:
+		return "token authentication failed"
+	case EventStatusTokenExpired:
+		return "token expired"
+	case EventStatusResumeFailed:
+		return "resume failed"
+	case EventStatusSessionExpired:
+		return "session expired"
+	case EventStatusInvalidSequenceNumber:
+		return "invalid sequence number"
+	default:
+		return fmt.Sprintf("unknown status %d", int(c))
+	}
+}
+
+// GatewayError is the error when the gateway refuses a connection with a status code in the hello signal.
+//
+// FYI: https://developer.kookapp.cn/doc/websocket
+type GatewayError struct {
+	Code EventStatusCode
+	// Resuming reports whether the connection tried to resume the previous gateway session.
+	Resuming bool
+}
+
+// Error provides the formatted error string
+func (e *GatewayError) Error() string {
+	return fmt.Sprintf("expecting status ok, received %d (%s)", int(e.Code), e.Code.Error())
+}
+
+// Unwrap returns the status code, so that errors.Is matches the EventStatusCode consts.
+func (e *GatewayError) Unwrap() error {
+	return e.Code
+}
+
+// Fatal reports whether retrying would fail again, which is EventStatusInvalidToken and EventStatusTokenAuthFailed.
+// Other codes, including EventStatusTokenExpired, are retried with a new gateway.
+func (e *GatewayError) Fatal() bool {
+	switch e.Code {
+	case EventStatusInvalidToken, EventStatusTokenAuthFailed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package kook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGatewayError(t *testing.T) {
+	var err error = &GatewayError{Code: EventStatusInvalidToken}
+	err = fmt.Errorf("open: %w", err)
+	if !errors.Is(err, EventStatusInvalidToken) {
+		t.Error(err)
+	}
+	if errors.Is(err, EventStatusTokenExpired) {
+		t.Error(err)
+	}
+	for _, code := range []EventStatusCode{EventStatusTokenExpired, EventStatusMissingArgument, EventStatusResumeFailed} {
+		if (&GatewayError{Code: code}).Fatal() {
+			t.Error(code)
+		}
+	}
+	var ge *GatewayError
+	if !errors.As(err, &ge) || !ge.Fatal() {
+		t.Error(err)
+	}
+	if get := ge.Error(); get != "expecting status ok, received 40101 (invalid token)" {
+		t.Error(get)
+	}
+}
+
+func TestEventStatusCode(t *testing.T) {
+	documented := map[EventStatusCode]int{
+		EventStatusOk:                    0,
+		EventStatusMissingArgument:       40100,
+		EventStatusInvalidToken:          40101,
+		EventStatusTokenAuthFailed:       40102,
+		EventStatusTokenExpired:          40103,
+		EventStatusResumeFailed:          40106,
+		EventStatusSessionExpired:        40107,
+		EventStatusInvalidSequenceNumber: 40108,
+	}
+	for code, want := range documented {
+		if int(code) != want {
+			t.Error(code.Error(), int(code), want)
+		}
+	}
+	var h EventDataHello
+	if err := json.Unmarshal([]byte(`{"code":40101}`), &h); err != nil {
+		t.Fatal(err)
+	}
+	if err := error(&GatewayError{Code: h.Code}); !errors.Is(err, EventStatusInvalidToken) {
+		t.Error(err)
+	}
+}
@@ -6,4 +6,5 @@ require (
 	github.com/bits-and-blooms/bitset v1.2.2 // indirect
 	github.com/bits-and-blooms/bloom/v3 v3.2.0
 	github.com/gorilla/websocket v1.5.0
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,96 @@
+package kook
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	defaultHeartbeatInterval = 30 * time.Second
+	defaultHeartbeatTimeout  = 6 * time.Second
+	defaultHeartbeatJitter   = 5 * time.Second
+
+	latencyWindowSize = 32
+)
+
+// heartbeatRetries are the waits before extra pings when a heartbeat is not acknowledged, as required by kook.
+var heartbeatRetries = []time.Duration{2 * time.Second, 4 * time.Second}
+
+// SessionWithHeartbeatInterval changes the interval between heartbeats, which is 30 seconds by default.
+func SessionWithHeartbeatInterval(d time.Duration) SessionOption {
+	return func(session *Session) {
+		session.heartbeatInterval = d
+	}
+}
+
+// SessionWithHeartbeatTimeout changes the minimum time waiting for a heartbeat ack, which is 6 seconds by default.
+//
+// The actual timeout grows with the observed latency, see HeartbeatStats.
+func SessionWithHeartbeatTimeout(d time.Duration) SessionOption {
+	return func(session *Session) {
+		session.heartbeatTimeout = d
+	}
+}
+
+// SessionWithHeartbeatJitter changes the random jitter added to the heartbeat interval, which is 5 seconds by default.
+func SessionWithHeartbeatJitter(d time.Duration) SessionOption {
+	return func(session *Session) {
+		session.heartbeatJitter = d
+	}
+}
+
+// HeartbeatStats is the rolling statistics of heartbeat round trip time.
+type HeartbeatStats struct {
+	Last    time.Duration `json:"last"`
+	Mean    time.Duration `json:"mean"`
+	P95     time.Duration `json:"p95"`
+	Samples int           `json:"samples"`
+}
+
+// latencyWindow keeps the latest heartbeat latencies.
+type latencyWindow struct {
+	samples [latencyWindowSize]time.Duration
+	count   int
+	next    int
+}
+
+func (w *latencyWindow) add(d time.Duration) {
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % latencyWindowSize
+	if w.count < latencyWindowSize {
+		w.count++
+	}
+}
+
+func (w *latencyWindow) stats() (hs HeartbeatStats) {
+	if w.count == 0 {
+		return
+	}
+	hs.Samples = w.count
+	hs.Last = w.samples[(w.next+latencyWindowSize-1)%latencyWindowSize]
+	sorted := make([]time.Duration, w.count)
+	copy(sorted, w.samples[:w.count])
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	var sum time.Duration
+	for _, item := range sorted {
+		sum += item
+	}
+	hs.Mean = sum / time.Duration(w.count)
+	hs.P95 = sorted[(w.count*95+99)/100-1]
+	return
+}
+
+// timeout returns the time waiting for a heartbeat ack, which is the configured timeout
+// or twice the 95th percentile latency, whichever is larger, and never exceeds the interval.
+func (w *latencyWindow) timeout(min, max time.Duration) time.Duration {
+	t := 2 * w.stats().P95
+	if t < min {
+		t = min
+	}
+	if max > 0 && t > max {
+		t = max
+	}
+	return t
+}
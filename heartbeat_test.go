@@ -0,0 +1,36 @@
+package kook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyWindow_stats(t *testing.T) {
+	w := &latencyWindow{}
+	if get := w.stats(); get.Samples != 0 {
+		t.Error(get)
+	}
+	for i := 1; i <= 20; i++ {
+		w.add(time.Duration(i) * time.Millisecond)
+	}
+	get := w.stats()
+	if get.Samples != 20 || get.Last != 20*time.Millisecond || get.P95 != 19*time.Millisecond {
+		t.Error(get)
+	}
+	if get.Mean != 10500*time.Microsecond {
+		t.Error(get.Mean)
+	}
+	for i := 0; i < latencyWindowSize; i++ {
+		w.add(time.Second)
+	}
+	get = w.stats()
+	if get.Samples != latencyWindowSize || get.Mean != time.Second {
+		t.Error(get)
+	}
+	if get := w.timeout(6*time.Second, 30*time.Second); get != 6*time.Second {
+		t.Error(get)
+	}
+	if get := w.timeout(time.Second, 30*time.Second); get != 2*time.Second {
+		t.Error(get)
+	}
+}
@@ -0,0 +1,345 @@
+package kook
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// HistoryDirection is the type for the direction of walking message history.
+type HistoryDirection int
+
+// These are the directions of walking message history.
+const (
+	// HistoryBackward walks from newer messages to older ones.
+	HistoryBackward HistoryDirection = iota
+	// HistoryForward walks from older messages to newer ones.
+	HistoryForward
+)
+
+// HistoryOption is the type for optional arguments of message history iterators.
+type HistoryOption func(*historyConfig)
+
+type historyConfig struct {
+	direction HistoryDirection
+	fromID    string
+	fromTime  time.Time
+	until     time.Time
+	pageSize  int
+}
+
+// HistoryWithDirection sets the direction of walking, HistoryBackward by default.
+func HistoryWithDirection(d HistoryDirection) HistoryOption {
+	return func(c *historyConfig) {
+		c.direction = d
+	}
+}
+
+// HistoryFromMessage starts walking next to the message, which itself is not included.
+func HistoryFromMessage(msgID string) HistoryOption {
+	return func(c *historyConfig) {
+		c.fromID = msgID
+	}
+}
+
+// HistoryFromTime starts walking from the time, including messages sent at the time.
+//
+// Walking forward from a time first walks backward from the latest message to find where to start.
+// It is ignored if HistoryFromMessage is set.
+func HistoryFromTime(t time.Time) HistoryOption {
+	return func(c *historyConfig) {
+		c.fromTime = t
+	}
+}
+
+// HistoryUntil stops walking at messages sent before the time when walking backward, or after it when walking forward.
+func HistoryUntil(t time.Time) HistoryOption {
+	return func(c *historyConfig) {
+		c.until = t
+	}
+}
+
+// HistoryWithPageSize sets the number of messages fetched in a request.
+func HistoryWithPageSize(size int) HistoryOption {
+	return func(c *historyConfig) {
+		c.pageSize = size
+	}
+}
+
+type historyItem struct {
+	id       string
+	createAt MilliTimeStamp
+	value    interface{}
+}
+
+// history walks message history with a msg_id cursor, shared by channel and direct messages.
+type history struct {
+	ctx      context.Context
+	session  *Session
+	endpoint string
+	fetch    func(msgID string, flag MessageListFlag, size int) ([]historyItem, error)
+	config   historyConfig
+
+	started  bool
+	cursor   string
+	boundary map[string]bool
+	buf      []historyItem
+	cur      interface{}
+	done     bool
+	err      error
+}
+
+func newHistory(ctx context.Context, s *Session, endpoint string, options []HistoryOption) history {
+	h := history{ctx: ctx, session: s, endpoint: endpoint}
+	for _, item := range options {
+		item(&h.config)
+	}
+	return h
+}
+
+func (h *history) next() bool {
+	for {
+		for len(h.buf) == 0 {
+			if h.done || h.err != nil {
+				h.cur = nil
+				return false
+			}
+			if !h.started {
+				h.start()
+			} else {
+				h.load()
+			}
+		}
+		item := h.buf[0]
+		h.buf = h.buf[1:]
+		if !h.config.until.IsZero() {
+			until := MilliTimeStampOfTime(h.config.until)
+			if h.config.direction == HistoryBackward && item.createAt < until ||
+				h.config.direction == HistoryForward && item.createAt > until {
+				h.done = true
+				h.buf = nil
+				continue
+			}
+		}
+		if h.config.direction == HistoryBackward && h.config.fromID == "" && !h.config.fromTime.IsZero() &&
+			item.createAt > MilliTimeStampOfTime(h.config.fromTime) {
+			continue
+		}
+		h.cur = item.value
+		return true
+	}
+}
+
+// page fetches the messages next to msgID sorted from older to newer.
+func (h *history) page(msgID string, flag MessageListFlag) ([]historyItem, error) {
+	if err := h.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if h.session.RateLimiter != nil {
+		if err := h.session.RateLimiter.Wait(h.ctx, h.session.rateLimitKey(h.endpoint)); err != nil {
+			return nil, err
+		}
+	}
+	items, err := h.fetch(msgID, flag, h.config.pageSize)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].createAt < items[j].createAt
+	})
+	return items, nil
+}
+
+// fresh drops the cursor and messages of the previous page, as pages could overlap at the boundary.
+func fresh(items []historyItem, cursor string, boundary map[string]bool) []historyItem {
+	f := items[:0]
+	for _, item := range items {
+		if item.id == cursor || boundary[item.id] {
+			continue
+		}
+		f = append(f, item)
+	}
+	return f
+}
+
+func (h *history) start() {
+	h.started = true
+	if h.config.fromID != "" || h.config.direction == HistoryBackward {
+		h.cursor = h.config.fromID
+		h.load()
+		return
+	}
+	// Walk back to the newest message before fromTime, or the oldest message.
+	from := MilliTimeStampOfTime(h.config.fromTime)
+	var oldest *historyItem
+	for {
+		items, err := h.page(h.cursor, MessageListFlagBefore)
+		if err != nil {
+			h.err = err
+			return
+		}
+		items = fresh(items, h.cursor, h.boundary)
+		if len(items) == 0 {
+			if oldest == nil {
+				h.done = true
+				return
+			}
+			h.cursor = oldest.id
+			h.buf = []historyItem{*oldest}
+			h.boundary = map[string]bool{oldest.id: true}
+			return
+		}
+		if !h.config.fromTime.IsZero() {
+			for i := len(items) - 1; i >= 0; i-- {
+				if items[i].createAt < from {
+					h.cursor = items[i].id
+					h.boundary = nil
+					return
+				}
+			}
+		}
+		oldest = &items[0]
+		h.cursor = oldest.id
+		h.boundary = map[string]bool{}
+		for _, item := range items {
+			h.boundary[item.id] = true
+		}
+	}
+}
+
+func (h *history) load() {
+	flag := MessageListFlagBefore
+	if h.config.direction == HistoryForward {
+		flag = MessageListFlagAfter
+	}
+	items, err := h.page(h.cursor, flag)
+	if err != nil {
+		h.err = err
+		return
+	}
+	items = fresh(items, h.cursor, h.boundary)
+	if len(items) == 0 {
+		h.done = true
+		return
+	}
+	h.boundary = make(map[string]bool, len(items))
+	for _, item := range items {
+		h.boundary[item.id] = true
+	}
+	if h.config.direction == HistoryBackward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+	h.cursor = items[len(items)-1].id
+	h.buf = items
+}
+
+// MessageIterator iterates over the message history of a channel, fetching pages lazily.
+type MessageIterator struct {
+	h history
+}
+
+// Next advances to the next message. It returns false when there are no more messages or on error.
+func (it *MessageIterator) Next() bool {
+	return it.h.next()
+}
+
+// Item returns the current message.
+func (it *MessageIterator) Item() *DetailedChannelMessage {
+	m, _ := it.h.cur.(*DetailedChannelMessage)
+	return m
+}
+
+// Err returns the error which stopped the iteration.
+func (it *MessageIterator) Err() error {
+	return it.h.err
+}
+
+// Collect fetches all remaining messages.
+func (it *MessageIterator) Collect() ([]*DetailedChannelMessage, error) {
+	var items []*DetailedChannelMessage
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// MessageListAll returns an iterator over the message history of a channel, from the latest message backward by default.
+func (s *Session) MessageListAll(ctx context.Context, targetID string, options ...HistoryOption) *MessageIterator {
+	h := newHistory(ctx, s, EndpointMessageList, options)
+	h.fetch = func(msgID string, flag MessageListFlag, size int) ([]historyItem, error) {
+		var opts []MessageListOption
+		if msgID != "" {
+			opts = append(opts, MessageListWithMsgID(msgID), MessageListWithFlag(flag))
+		}
+		if size > 0 {
+			opts = append(opts, MessageListWithPageSize(size))
+		}
+		ms, err := s.MessageList(targetID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]historyItem, 0, len(ms))
+		for _, m := range ms {
+			items = append(items, historyItem{id: m.ID, createAt: m.CreateAt, value: m})
+		}
+		return items, nil
+	}
+	return &MessageIterator{h: h}
+}
+
+// DirectMessageIterator iterates over the message history of a direct chat, fetching pages lazily.
+type DirectMessageIterator struct {
+	h history
+}
+
+// Next advances to the next message. It returns false when there are no more messages or on error.
+func (it *DirectMessageIterator) Next() bool {
+	return it.h.next()
+}
+
+// Item returns the current message.
+func (it *DirectMessageIterator) Item() *DirectMessageResp {
+	m, _ := it.h.cur.(*DirectMessageResp)
+	return m
+}
+
+// Err returns the error which stopped the iteration.
+func (it *DirectMessageIterator) Err() error {
+	return it.h.err
+}
+
+// Collect fetches all remaining messages.
+func (it *DirectMessageIterator) Collect() ([]*DirectMessageResp, error) {
+	var items []*DirectMessageResp
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// DirectMessageListAll returns an iterator over the message history of a direct chat by chat code,
+// from the latest message backward by default.
+func (s *Session) DirectMessageListAll(ctx context.Context, chatCode string, options ...HistoryOption) *DirectMessageIterator {
+	h := newHistory(ctx, s, EndpointDirectMessageList, options)
+	h.fetch = func(msgID string, flag MessageListFlag, size int) ([]historyItem, error) {
+		opts := []DirectMessageListOption{DirectMessageListWithChatCode(chatCode)}
+		if msgID != "" {
+			opts = append(opts, DirectMessageListWithMsgID(msgID), DirectMessageListWithFlag(flag))
+		}
+		if size > 0 {
+			opts = append(opts, DirectMessageListWithPageSize(size))
+		}
+		ms, err := s.DirectMessageList(opts...)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]historyItem, 0, len(ms))
+		for _, m := range ms {
+			items = append(items, historyItem{id: m.ID, createAt: m.CreateAt, value: m})
+		}
+		return items, nil
+	}
+	return &DirectMessageIterator{h: h}
+}
@@ -0,0 +1,72 @@
+package kook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newHistoryTestServer(t *testing.T, n int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		size, _ := strconv.Atoi(q.Get("page_size"))
+		ref, _ := strconv.Atoi(q.Get("msg_id"))
+		lo, hi := n-size+1, n
+		switch q.Get("flag") {
+		case "before":
+			// The reference message is included, as kook may do.
+			lo, hi = ref-size+1, ref
+		case "after":
+			lo, hi = ref, ref+size-1
+		}
+		var items []*DetailedChannelMessage
+		for i := lo; i <= hi; i++ {
+			if i >= 1 && i <= n {
+				items = append(items, &DetailedChannelMessage{ID: strconv.Itoa(i), CreateAt: MilliTimeStamp(i * 1000)})
+			}
+		}
+		data, _ := json.Marshal(map[string]interface{}{"items": items})
+		json.NewEncoder(w).Encode(EndpointGeneralResponse{Data: data})
+	}))
+}
+
+func historyIDs(ms []*DetailedChannelMessage) string {
+	ids := ""
+	for _, m := range ms {
+		ids += m.ID + ","
+	}
+	return ids
+}
+
+func TestMessageIterator(t *testing.T) {
+	ts := newHistoryTestServer(t, 10)
+	defer ts.Close()
+	s := New("", mockLogger{}, SessionWithAPIBase(ts.URL))
+	ctx := context.Background()
+	ms, err := s.MessageListAll(ctx, "c", HistoryWithPageSize(3)).Collect()
+	if get := historyIDs(ms); err != nil || get != "10,9,8,7,6,5,4,3,2,1," {
+		t.Error(get, err)
+	}
+	ms, err = s.MessageListAll(ctx, "c", HistoryWithPageSize(3), HistoryWithDirection(HistoryForward),
+		HistoryFromMessage("4"), HistoryUntil(time.Unix(8, 0))).Collect()
+	if get := historyIDs(ms); err != nil || get != "5,6,7,8," {
+		t.Error(get, err)
+	}
+	ms, err = s.MessageListAll(ctx, "c", HistoryWithPageSize(4), HistoryWithDirection(HistoryForward),
+		HistoryFromTime(time.Unix(3, 0))).Collect()
+	if get := historyIDs(ms); err != nil || get != "3,4,5,6,7,8,9,10," {
+		t.Error(get, err)
+	}
+	ms, err = s.MessageListAll(ctx, "c", HistoryWithPageSize(4), HistoryWithDirection(HistoryForward)).Collect()
+	if get := historyIDs(ms); err != nil || get != "1,2,3,4,5,6,7,8,9,10," {
+		t.Error(get, err)
+	}
+	ms, err = s.MessageListAll(ctx, "c", HistoryWithPageSize(4), HistoryFromTime(time.Unix(7, 0)), HistoryUntil(time.Unix(4, 0))).Collect()
+	if get := historyIDs(ms); err != nil || get != "7,6,5,4," {
+		t.Error(get, err)
+	}
+}
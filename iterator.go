@@ -0,0 +1,684 @@
+package kook
+
+import "context"
+
+// pager fetches the pages of a list endpoint lazily for the iterators.
+type pager struct {
+	ctx      context.Context
+	session  *Session
+	endpoint string
+	setting  PageSetting
+	next     int
+	meta     *PageInfo
+	done     bool
+	err      error
+}
+
+func newPager(ctx context.Context, s *Session, endpoint string, page *PageSetting) pager {
+	p := pager{ctx: ctx, session: s, endpoint: endpoint, next: 1}
+	if page != nil {
+		p.setting = *page
+		if page.Page != nil {
+			p.next = *page.Page
+		}
+	}
+	p.setting.ctx = ctx
+	return p
+}
+
+// fetch loads pages with load until one has items, it returns false when all pages are fetched or on error.
+func (p *pager) fetch(load func(page *PageSetting) (n int, meta *PageInfo, err error)) bool {
+	for !p.done && p.err == nil {
+		if p.err = p.ctx.Err(); p.err != nil {
+			return false
+		}
+		if p.session.RateLimiter != nil {
+			p.err = p.session.RateLimiter.Wait(p.ctx, p.session.rateLimitKey(p.endpoint))
+			if p.err != nil {
+				return false
+			}
+		}
+		page := p.setting
+		current := p.next
+		page.Page = &current
+		n, meta, err := load(&page)
+		if err != nil {
+			p.err = err
+			return false
+		}
+		p.next++
+		p.meta = meta
+		if n == 0 || meta == nil || meta.Page >= meta.PageTotal {
+			p.done = true
+		}
+		if n != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ChannelIterator iterates over channels, fetching pages lazily.
+type ChannelIterator struct {
+	p    pager
+	load func(page *PageSetting) ([]*Channel, *PageInfo, error)
+	buf  []*Channel
+	cur  *Channel
+}
+
+// Next advances to the next channel. It returns false when there are no more channels or on error.
+func (it *ChannelIterator) Next() bool {
+	if len(it.buf) == 0 && !it.p.fetch(func(page *PageSetting) (int, *PageInfo, error) {
+		items, meta, err := it.load(page)
+		it.buf = items
+		return len(items), meta, err
+	}) {
+		it.cur = nil
+		return false
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current channel.
+func (it *ChannelIterator) Item() *Channel {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration.
+func (it *ChannelIterator) Err() error {
+	return it.p.err
+}
+
+// PageInfo returns the page info of the last fetched page.
+func (it *ChannelIterator) PageInfo() *PageInfo {
+	return it.p.meta
+}
+
+// Collect fetches all remaining channels.
+func (it *ChannelIterator) Collect() ([]*Channel, error) {
+	var items []*Channel
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// ChannelListAll returns an iterator over all channels of a guild, starting from the page in the setting.
+func (s *Session) ChannelListAll(ctx context.Context, guildID string, page *PageSetting) *ChannelIterator {
+	return &ChannelIterator{
+		p: newPager(ctx, s, EndpointChannelList, page),
+		load: func(page *PageSetting) ([]*Channel, *PageInfo, error) {
+			return s.ChannelList(guildID, page)
+		},
+	}
+}
+
+// UserInVoiceChannelIterator iterates over voice channels, fetching pages lazily.
+type UserInVoiceChannelIterator struct {
+	p    pager
+	load func(page *PageSetting) ([]*UserInVoiceChannel, *PageInfo, error)
+	buf  []*UserInVoiceChannel
+	cur  *UserInVoiceChannel
+}
+
+// Next advances to the next voice channel. It returns false when there are no more voice channels or on error.
+func (it *UserInVoiceChannelIterator) Next() bool {
+	if len(it.buf) == 0 && !it.p.fetch(func(page *PageSetting) (int, *PageInfo, error) {
+		items, meta, err := it.load(page)
+		it.buf = items
+		return len(items), meta, err
+	}) {
+		it.cur = nil
+		return false
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current voice channel.
+func (it *UserInVoiceChannelIterator) Item() *UserInVoiceChannel {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration.
+func (it *UserInVoiceChannelIterator) Err() error {
+	return it.p.err
+}
+
+// PageInfo returns the page info of the last fetched page.
+func (it *UserInVoiceChannelIterator) PageInfo() *PageInfo {
+	return it.p.meta
+}
+
+// Collect fetches all remaining voice channels.
+func (it *UserInVoiceChannelIterator) Collect() ([]*UserInVoiceChannel, error) {
+	var items []*UserInVoiceChannel
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// ChannelUserGetJoinedChannelAll returns an iterator over all voice channels the user joined in a guild, starting from the page in the setting.
+func (s *Session) ChannelUserGetJoinedChannelAll(ctx context.Context, guildID, userID string, page *PageSetting) *UserInVoiceChannelIterator {
+	return &UserInVoiceChannelIterator{
+		p: newPager(ctx, s, EndpointChannelUserGetJoinedChannel, page),
+		load: func(page *PageSetting) ([]*UserInVoiceChannel, *PageInfo, error) {
+			return s.ChannelUserGetJoinedChannel(guildID, userID, page)
+		},
+	}
+}
+
+// UserChatIterator iterates over user chats, fetching pages lazily.
+type UserChatIterator struct {
+	p    pager
+	load func(page *PageSetting) ([]*UserChat, *PageInfo, error)
+	buf  []*UserChat
+	cur  *UserChat
+}
+
+// Next advances to the next user chat. It returns false when there are no more user chats or on error.
+func (it *UserChatIterator) Next() bool {
+	if len(it.buf) == 0 && !it.p.fetch(func(page *PageSetting) (int, *PageInfo, error) {
+		items, meta, err := it.load(page)
+		it.buf = items
+		return len(items), meta, err
+	}) {
+		it.cur = nil
+		return false
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current user chat.
+func (it *UserChatIterator) Item() *UserChat {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration.
+func (it *UserChatIterator) Err() error {
+	return it.p.err
+}
+
+// PageInfo returns the page info of the last fetched page.
+func (it *UserChatIterator) PageInfo() *PageInfo {
+	return it.p.meta
+}
+
+// Collect fetches all remaining user chats.
+func (it *UserChatIterator) Collect() ([]*UserChat, error) {
+	var items []*UserChat
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// UserChatListAll returns an iterator over all user chats of the bot, starting from the page in the setting.
+func (s *Session) UserChatListAll(ctx context.Context, page *PageSetting) *UserChatIterator {
+	return &UserChatIterator{
+		p: newPager(ctx, s, EndpointUserChatList, page),
+		load: func(page *PageSetting) ([]*UserChat, *PageInfo, error) {
+			return s.UserChatList(page)
+		},
+	}
+}
+
+// GuildIterator iterates over guilds, fetching pages lazily.
+type GuildIterator struct {
+	p    pager
+	load func(page *PageSetting) ([]*Guild, *PageInfo, error)
+	buf  []*Guild
+	cur  *Guild
+}
+
+// Next advances to the next guild. It returns false when there are no more guilds or on error.
+func (it *GuildIterator) Next() bool {
+	if len(it.buf) == 0 && !it.p.fetch(func(page *PageSetting) (int, *PageInfo, error) {
+		items, meta, err := it.load(page)
+		it.buf = items
+		return len(items), meta, err
+	}) {
+		it.cur = nil
+		return false
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current guild.
+func (it *GuildIterator) Item() *Guild {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration.
+func (it *GuildIterator) Err() error {
+	return it.p.err
+}
+
+// PageInfo returns the page info of the last fetched page.
+func (it *GuildIterator) PageInfo() *PageInfo {
+	return it.p.meta
+}
+
+// Collect fetches all remaining guilds.
+func (it *GuildIterator) Collect() ([]*Guild, error) {
+	var items []*Guild
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// GuildListAll returns an iterator over all guilds the bot joins, starting from the page in the setting.
+func (s *Session) GuildListAll(ctx context.Context, page *PageSetting) *GuildIterator {
+	return &GuildIterator{
+		p: newPager(ctx, s, EndpointGuildList, page),
+		load: func(page *PageSetting) ([]*Guild, *PageInfo, error) {
+			return s.GuildList(page)
+		},
+	}
+}
+
+// GuildBoostHistoryIterator iterates over boost history items, fetching pages lazily.
+type GuildBoostHistoryIterator struct {
+	p    pager
+	load func(page *PageSetting) ([]*GuildBoostHistoryItem, *PageInfo, error)
+	buf  []*GuildBoostHistoryItem
+	cur  *GuildBoostHistoryItem
+}
+
+// Next advances to the next boost history item. It returns false when there are no more boost history items or on error.
+func (it *GuildBoostHistoryIterator) Next() bool {
+	if len(it.buf) == 0 && !it.p.fetch(func(page *PageSetting) (int, *PageInfo, error) {
+		items, meta, err := it.load(page)
+		it.buf = items
+		return len(items), meta, err
+	}) {
+		it.cur = nil
+		return false
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current boost history item.
+func (it *GuildBoostHistoryIterator) Item() *GuildBoostHistoryItem {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration.
+func (it *GuildBoostHistoryIterator) Err() error {
+	return it.p.err
+}
+
+// PageInfo returns the page info of the last fetched page.
+func (it *GuildBoostHistoryIterator) PageInfo() *PageInfo {
+	return it.p.meta
+}
+
+// Collect fetches all remaining boost history items.
+func (it *GuildBoostHistoryIterator) Collect() ([]*GuildBoostHistoryItem, error) {
+	var items []*GuildBoostHistoryItem
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// GuildBoostHistoryAll returns an iterator over the boost history of a guild, starting from the page in the setting.
+func (s *Session) GuildBoostHistoryAll(ctx context.Context, guildID string, page *PageSetting, options ...GuildBoostHistoryOption) *GuildBoostHistoryIterator {
+	return &GuildBoostHistoryIterator{
+		p: newPager(ctx, s, EndpointGuildBoostHistory, page),
+		load: func(page *PageSetting) ([]*GuildBoostHistoryItem, *PageInfo, error) {
+			return s.GuildBoostHistory(guildID, page, options...)
+		},
+	}
+}
+
+// RoleIterator iterates over roles, fetching pages lazily.
+type RoleIterator struct {
+	p    pager
+	load func(page *PageSetting) ([]*Role, *PageInfo, error)
+	buf  []*Role
+	cur  *Role
+}
+
+// Next advances to the next role. It returns false when there are no more roles or on error.
+func (it *RoleIterator) Next() bool {
+	if len(it.buf) == 0 && !it.p.fetch(func(page *PageSetting) (int, *PageInfo, error) {
+		items, meta, err := it.load(page)
+		it.buf = items
+		return len(items), meta, err
+	}) {
+		it.cur = nil
+		return false
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current role.
+func (it *RoleIterator) Item() *Role {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration.
+func (it *RoleIterator) Err() error {
+	return it.p.err
+}
+
+// PageInfo returns the page info of the last fetched page.
+func (it *RoleIterator) PageInfo() *PageInfo {
+	return it.p.meta
+}
+
+// Collect fetches all remaining roles.
+func (it *RoleIterator) Collect() ([]*Role, error) {
+	var items []*Role
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// GuildRoleListAll returns an iterator over all roles of a guild, starting from the page in the setting.
+func (s *Session) GuildRoleListAll(ctx context.Context, guildID string, page *PageSetting) *RoleIterator {
+	return &RoleIterator{
+		p: newPager(ctx, s, EndpointGuildRoleList, page),
+		load: func(page *PageSetting) ([]*Role, *PageInfo, error) {
+			return s.GuildRoleList(guildID, page)
+		},
+	}
+}
+
+// GuildEmojiIterator iterates over emojis, fetching pages lazily.
+type GuildEmojiIterator struct {
+	p    pager
+	load func(page *PageSetting) ([]*GuildEmojiResp, *PageInfo, error)
+	buf  []*GuildEmojiResp
+	cur  *GuildEmojiResp
+}
+
+// Next advances to the next emoji. It returns false when there are no more emojis or on error.
+func (it *GuildEmojiIterator) Next() bool {
+	if len(it.buf) == 0 && !it.p.fetch(func(page *PageSetting) (int, *PageInfo, error) {
+		items, meta, err := it.load(page)
+		it.buf = items
+		return len(items), meta, err
+	}) {
+		it.cur = nil
+		return false
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current emoji.
+func (it *GuildEmojiIterator) Item() *GuildEmojiResp {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration.
+func (it *GuildEmojiIterator) Err() error {
+	return it.p.err
+}
+
+// PageInfo returns the page info of the last fetched page.
+func (it *GuildEmojiIterator) PageInfo() *PageInfo {
+	return it.p.meta
+}
+
+// Collect fetches all remaining emojis.
+func (it *GuildEmojiIterator) Collect() ([]*GuildEmojiResp, error) {
+	var items []*GuildEmojiResp
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// GuildEmojiListAll returns an iterator over all emojis of a guild, starting from the page in the setting.
+func (s *Session) GuildEmojiListAll(ctx context.Context, guildID string, page *PageSetting) *GuildEmojiIterator {
+	return &GuildEmojiIterator{
+		p: newPager(ctx, s, EndpointGuildEmojiList, page),
+		load: func(page *PageSetting) ([]*GuildEmojiResp, *PageInfo, error) {
+			return s.GuildEmojiList(guildID, page)
+		},
+	}
+}
+
+// InviteIterator iterates over invites, fetching pages lazily.
+type InviteIterator struct {
+	p    pager
+	load func(page *PageSetting) ([]*InviteListResp, *PageInfo, error)
+	buf  []*InviteListResp
+	cur  *InviteListResp
+}
+
+// Next advances to the next invite. It returns false when there are no more invites or on error.
+func (it *InviteIterator) Next() bool {
+	if len(it.buf) == 0 && !it.p.fetch(func(page *PageSetting) (int, *PageInfo, error) {
+		items, meta, err := it.load(page)
+		it.buf = items
+		return len(items), meta, err
+	}) {
+		it.cur = nil
+		return false
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current invite.
+func (it *InviteIterator) Item() *InviteListResp {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration.
+func (it *InviteIterator) Err() error {
+	return it.p.err
+}
+
+// PageInfo returns the page info of the last fetched page.
+func (it *InviteIterator) PageInfo() *PageInfo {
+	return it.p.meta
+}
+
+// Collect fetches all remaining invites.
+func (it *InviteIterator) Collect() ([]*InviteListResp, error) {
+	var items []*InviteListResp
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// InviteListAll returns an iterator over all invites, starting from the page in the setting.
+func (s *Session) InviteListAll(ctx context.Context, page *PageSetting, options ...InviteListOption) *InviteIterator {
+	return &InviteIterator{
+		p: newPager(ctx, s, EndpointInviteList, page),
+		load: func(page *PageSetting) ([]*InviteListResp, *PageInfo, error) {
+			return s.InviteList(page, options...)
+		},
+	}
+}
+
+// BlacklistIterator iterates over blacklist items, fetching pages lazily.
+type BlacklistIterator struct {
+	p    pager
+	load func(page *PageSetting) ([]*BlacklistItem, *PageInfo, error)
+	buf  []*BlacklistItem
+	cur  *BlacklistItem
+}
+
+// Next advances to the next blacklist item. It returns false when there are no more blacklist items or on error.
+func (it *BlacklistIterator) Next() bool {
+	if len(it.buf) == 0 && !it.p.fetch(func(page *PageSetting) (int, *PageInfo, error) {
+		items, meta, err := it.load(page)
+		it.buf = items
+		return len(items), meta, err
+	}) {
+		it.cur = nil
+		return false
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current blacklist item.
+func (it *BlacklistIterator) Item() *BlacklistItem {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration.
+func (it *BlacklistIterator) Err() error {
+	return it.p.err
+}
+
+// PageInfo returns the page info of the last fetched page.
+func (it *BlacklistIterator) PageInfo() *PageInfo {
+	return it.p.meta
+}
+
+// Collect fetches all remaining blacklist items.
+func (it *BlacklistIterator) Collect() ([]*BlacklistItem, error) {
+	var items []*BlacklistItem
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// BlacklistListAll returns an iterator over the blacklist of a guild, starting from the page in the setting.
+func (s *Session) BlacklistListAll(ctx context.Context, guildID string, page *PageSetting) *BlacklistIterator {
+	return &BlacklistIterator{
+		p: newPager(ctx, s, EndpointBlacklistList, page),
+		load: func(page *PageSetting) ([]*BlacklistItem, *PageInfo, error) {
+			return s.BlacklistList(guildID, page)
+		},
+	}
+}
+
+// GameIterator iterates over games, fetching pages lazily.
+type GameIterator struct {
+	p    pager
+	load func(page *PageSetting) ([]*Game, *PageInfo, error)
+	buf  []*Game
+	cur  *Game
+}
+
+// Next advances to the next game. It returns false when there are no more games or on error.
+func (it *GameIterator) Next() bool {
+	if len(it.buf) == 0 && !it.p.fetch(func(page *PageSetting) (int, *PageInfo, error) {
+		items, meta, err := it.load(page)
+		it.buf = items
+		return len(items), meta, err
+	}) {
+		it.cur = nil
+		return false
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current game.
+func (it *GameIterator) Item() *Game {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration.
+func (it *GameIterator) Err() error {
+	return it.p.err
+}
+
+// PageInfo returns the page info of the last fetched page.
+func (it *GameIterator) PageInfo() *PageInfo {
+	return it.p.meta
+}
+
+// Collect fetches all remaining games.
+func (it *GameIterator) Collect() ([]*Game, error) {
+	var items []*Game
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// GameListAll returns an iterator over all registered games, starting from the page in the setting.
+func (s *Session) GameListAll(ctx context.Context, page *PageSetting, options ...GameListOption) *GameIterator {
+	return &GameIterator{
+		p: newPager(ctx, s, EndpointGame, page),
+		load: func(page *PageSetting) ([]*Game, *PageInfo, error) {
+			return s.GameList(page, options...)
+		},
+	}
+}
+
+// GuildUserIterator iterates over users, fetching pages lazily.
+type GuildUserIterator struct {
+	p    pager
+	load func(page *PageSetting) ([]*User, *PageInfo, error)
+	buf  []*User
+	cur  *User
+	info *GuildUserListInfo
+}
+
+// Next advances to the next user. It returns false when there are no more users or on error.
+func (it *GuildUserIterator) Next() bool {
+	if len(it.buf) == 0 && !it.p.fetch(func(page *PageSetting) (int, *PageInfo, error) {
+		items, meta, err := it.load(page)
+		it.buf = items
+		return len(items), meta, err
+	}) {
+		it.cur = nil
+		return false
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Item returns the current user.
+func (it *GuildUserIterator) Item() *User {
+	return it.cur
+}
+
+// Err returns the error which stopped the iteration.
+func (it *GuildUserIterator) Err() error {
+	return it.p.err
+}
+
+// PageInfo returns the page info of the last fetched page.
+func (it *GuildUserIterator) PageInfo() *PageInfo {
+	return it.p.meta
+}
+
+// Collect fetches all remaining users.
+func (it *GuildUserIterator) Collect() ([]*User, error) {
+	var items []*User
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// Info returns the user counts reported with the last fetched page.
+func (it *GuildUserIterator) Info() *GuildUserListInfo {
+	return it.info
+}
+
+// GuildUserListAll returns an iterator over all users of a guild, starting from the page in the setting.
+func (s *Session) GuildUserListAll(ctx context.Context, guildID string, page *PageSetting, options ...GuildUserListOption) *GuildUserIterator {
+	it := &GuildUserIterator{p: newPager(ctx, s, EndpointGuildUserList, page)}
+	it.load = func(page *PageSetting) ([]*User, *PageInfo, error) {
+		us, info, meta, err := s.GuildUserList(guildID, page, options...)
+		if info != nil {
+			it.info = info
+		}
+		return us, meta, err
+	}
+	return it
+}
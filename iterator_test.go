@@ -0,0 +1,75 @@
+package kook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGuildUserIterator(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if r.URL.Query().Get("page_size") != "2" {
+			t.Error(r.URL)
+		}
+		items := `[{"id":"1"},{"id":"2"}]`
+		if page == "2" {
+			items = `[{"id":"3"}]`
+		}
+		fmt.Fprintf(w, `{"code":0,"message":"","data":{"items":%s,"meta":{"page":%s,"page_total":2,"page_size":2,"total":3},"user_count":3}}`, items, page)
+	}))
+	defer ts.Close()
+	s := New("", mockLogger{}, SessionWithAPIBase(ts.URL))
+	size := 2
+	it := s.GuildUserListAll(context.Background(), "guild", &PageSetting{PageSize: &size})
+	us, err := it.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(us) != 3 || us[0].ID != "1" || us[2].ID != "3" {
+		t.Error(us)
+	}
+	if it.Info() == nil || it.Info().UserCount != 3 {
+		t.Error(it.Info())
+	}
+	if it.Next() {
+		t.Error("iterator should be done")
+	}
+}
+
+func TestIterator_cancel(t *testing.T) {
+	s := New("", mockLogger{}, SessionWithAPIBase("http://127.0.0.1:0"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it := s.GuildListAll(ctx, nil)
+	if it.Next() || it.Err() != context.Canceled {
+		t.Error(it.Err())
+	}
+}
+
+func TestIterator_cancelRequest(t *testing.T) {
+	arrived := make(chan struct{}, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+			t.Error("request not canceled")
+		}
+	}))
+	defer ts.Close()
+	s := New("", mockLogger{}, SessionWithAPIBase(ts.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-arrived
+		cancel()
+	}()
+	it := s.GuildListAll(ctx, nil)
+	if it.Next() || !errors.Is(it.Err(), context.Canceled) {
+		t.Error(it.Err())
+	}
+}
@@ -0,0 +1,149 @@
+package kmarkdown
+
+import "strings"
+
+// Builder composes KMarkdown. Methods formatting text escape it like the functions with the same names.
+//
+//	content := kmarkdown.NewBuilder().
+//		MentionUser(userID).Text(" rolled ").Bold(result).Line().
+//		Quote(reason).
+//		String()
+type Builder struct {
+	b strings.Builder
+}
+
+// NewBuilder creates a Builder.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// String returns the KMarkdown built.
+func (b *Builder) String() string {
+	return b.b.String()
+}
+
+// Len returns the length of the KMarkdown built in bytes.
+func (b *Builder) Len() int {
+	return b.b.Len()
+}
+
+// Raw appends KMarkdown as is, e.g. the result of functions in this package.
+func (b *Builder) Raw(kmarkdown string) *Builder {
+	b.b.WriteString(kmarkdown)
+	return b
+}
+
+// Text appends the escaped text.
+func (b *Builder) Text(text string) *Builder {
+	return b.Raw(Escape(text))
+}
+
+// Line appends a line break.
+func (b *Builder) Line() *Builder {
+	return b.Raw("\n")
+}
+
+// Bold appends the text in bold.
+func (b *Builder) Bold(text string) *Builder {
+	return b.Raw(Bold(text))
+}
+
+// Italic appends the text in italic.
+func (b *Builder) Italic(text string) *Builder {
+	return b.Raw(Italic(text))
+}
+
+// BoldItalic appends the text in bold and italic.
+func (b *Builder) BoldItalic(text string) *Builder {
+	return b.Raw(BoldItalic(text))
+}
+
+// Strikethrough appends the text with a line through it.
+func (b *Builder) Strikethrough(text string) *Builder {
+	return b.Raw(Strikethrough(text))
+}
+
+// Underline appends the text with a line under it.
+func (b *Builder) Underline(text string) *Builder {
+	return b.Raw(Underline(text))
+}
+
+// Spoiler appends the text hidden until it is clicked.
+func (b *Builder) Spoiler(text string) *Builder {
+	return b.Raw(Spoiler(text))
+}
+
+// Color appends the text in the color of theme.
+func (b *Builder) Color(text string, theme Theme) *Builder {
+	return b.Raw(Color(text, theme))
+}
+
+// Link appends a link to the url displayed as text.
+func (b *Builder) Link(text, url string) *Builder {
+	return b.Raw(Link(text, url))
+}
+
+// Code appends the inline code.
+func (b *Builder) Code(code string) *Builder {
+	return b.Raw(Code(code))
+}
+
+// CodeBlock appends the code block, starting on a new line.
+func (b *Builder) CodeBlock(lang, code string) *Builder {
+	b.newLine()
+	return b.Raw(CodeBlock(lang, code))
+}
+
+// Quote appends the text as a quote, starting on a new line.
+func (b *Builder) Quote(text string) *Builder {
+	b.newLine()
+	return b.Raw(Quote(text))
+}
+
+// Divider appends a horizontal line.
+func (b *Builder) Divider() *Builder {
+	return b.Raw(Divider())
+}
+
+// MentionUser appends a mention of the user.
+func (b *Builder) MentionUser(userID string) *Builder {
+	return b.Raw(MentionUser(userID))
+}
+
+// MentionAll appends a mention of all users in the channel.
+func (b *Builder) MentionAll() *Builder {
+	return b.Raw(MentionAll())
+}
+
+// MentionHere appends a mention of online users in the channel.
+func (b *Builder) MentionHere() *Builder {
+	return b.Raw(MentionHere())
+}
+
+// MentionRole appends a mention of the role.
+func (b *Builder) MentionRole(roleID string) *Builder {
+	return b.Raw(MentionRole(roleID))
+}
+
+// Channel appends a link to the channel.
+func (b *Builder) Channel(channelID string) *Builder {
+	return b.Raw(Channel(channelID))
+}
+
+// Emoji appends an emoji by its short code.
+func (b *Builder) Emoji(name string) *Builder {
+	return b.Raw(Emoji(name))
+}
+
+// GuildEmoji appends a custom emoji of a guild.
+func (b *Builder) GuildEmoji(name, id string) *Builder {
+	return b.Raw(GuildEmoji(name, id))
+}
+
+// newLine starts a new line unless the KMarkdown built is empty or ends with a line break.
+func (b *Builder) newLine() {
+	s := b.b.String()
+	if s != "" && !strings.HasSuffix(s, "\n") {
+		b.b.WriteString("\n")
+	}
+}
@@ -0,0 +1,147 @@
+// Package kmarkdown formats KMarkdown, the markdown dialect of kook, for the content of messages of
+// MessageTypeKMarkdown and kmarkdown elements of card messages.
+//
+// Functions formatting text escape it, so content from users could be put into messages safely. Use Builder
+// to compose them.
+//
+// FYI: https://developer.kookapp.cn/doc/kmarkdown
+package kmarkdown
+
+import (
+	"strings"
+)
+
+// Theme is the type for colors of text.
+type Theme string
+
+// These are predefined usable themes of text.
+const (
+	ThemePrimary   Theme = "primary"
+	ThemeSuccess   Theme = "success"
+	ThemeDanger    Theme = "danger"
+	ThemeWarning   Theme = "warning"
+	ThemeInfo      Theme = "info"
+	ThemeSecondary Theme = "secondary"
+	ThemeBody      Theme = "body"
+	ThemeTips      Theme = "tips"
+	ThemePink      Theme = "pink"
+	ThemePurple    Theme = "purple"
+)
+
+var escaper = strings.NewReplacer(
+	`\`, `\\`,
+	`*`, `\*`,
+	`~`, `\~`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`(`, `\(`,
+	`)`, `\)`,
+	`>`, `\>`,
+	`-`, `\-`,
+	"`", "\\`",
+	`:`, `\:`,
+)
+
+// Escape escapes the special characters of KMarkdown in text, so it is displayed as is.
+func Escape(text string) string {
+	return escaper.Replace(text)
+}
+
+// Bold formats the text in bold.
+func Bold(text string) string {
+	return "**" + Escape(text) + "**"
+}
+
+// Italic formats the text in italic.
+func Italic(text string) string {
+	return "*" + Escape(text) + "*"
+}
+
+// BoldItalic formats the text in bold and italic.
+func BoldItalic(text string) string {
+	return "***" + Escape(text) + "***"
+}
+
+// Strikethrough formats the text with a line through it.
+func Strikethrough(text string) string {
+	return "~~" + Escape(text) + "~~"
+}
+
+// Underline formats the text with a line under it.
+func Underline(text string) string {
+	return "(ins)" + Escape(text) + "(ins)"
+}
+
+// Spoiler hides the text until it is clicked.
+func Spoiler(text string) string {
+	return "(spl)" + Escape(text) + "(spl)"
+}
+
+// Color formats the text in the color of theme.
+func Color(text string, theme Theme) string {
+	return "(font)" + Escape(text) + "(font)[" + string(theme) + "]"
+}
+
+var urlEscaper = strings.NewReplacer(`(`, `%28`, `)`, `%29`, ` `, `%20`)
+
+// Link formats a link to the url displayed as text. Only http and https links are clickable in kook.
+func Link(text, url string) string {
+	return "[" + Escape(text) + "](" + urlEscaper.Replace(url) + ")"
+}
+
+// Code formats the code inline. Code is not parsed as KMarkdown, and backticks in it are replaced with
+// single quotes as they could not be escaped.
+func Code(code string) string {
+	return "`" + strings.ReplaceAll(code, "`", "'") + "`"
+}
+
+// CodeBlock formats the code in a block highlighted as lang, which could be empty.
+// Triple backticks in the code are broken up by a zero width space as they end the block.
+func CodeBlock(lang, code string) string {
+	return "```" + lang + "\n" + strings.ReplaceAll(code, "```", "`\u200b``") + "\n```\n"
+}
+
+// Quote formats the text as a quote, which ends with an empty line.
+func Quote(text string) string {
+	return "> " + Escape(text) + "\n\n"
+}
+
+// Divider is a horizontal line on its own line.
+func Divider() string {
+	return "\n---\n"
+}
+
+// MentionUser mentions the user.
+func MentionUser(userID string) string {
+	return "(met)" + userID + "(met)"
+}
+
+// MentionAll mentions all users in the channel.
+func MentionAll() string {
+	return MentionUser("all")
+}
+
+// MentionHere mentions online users in the channel.
+func MentionHere() string {
+	return MentionUser("here")
+}
+
+// MentionRole mentions users of the role.
+func MentionRole(roleID string) string {
+	return "(rol)" + roleID + "(rol)"
+}
+
+// Channel links to the channel.
+func Channel(channelID string) string {
+	return "(chn)" + channelID + "(chn)"
+}
+
+// Emoji formats an emoji by its short code, e.g. "smile" for :smile:.
+func Emoji(name string) string {
+	return ":" + name + ":"
+}
+
+// GuildEmoji formats a custom emoji of a guild, whose id is like "1234567890/abcdefg".
+func GuildEmoji(name, id string) string {
+	return "(emj)" + name + "(emj)[" + id + "]"
+}
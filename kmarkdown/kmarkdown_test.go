@@ -0,0 +1,52 @@
+package kmarkdown
+
+import "testing"
+
+func TestEscape(t *testing.T) {
+	get := Escape(`**a** [b](c) :d: \ ~e~ > f - ` + "`g`")
+	expected := `\*\*a\*\* \[b\]\(c\) \:d\: \\ \~e\~ \> f \- ` + "\\`g\\`"
+	if get != expected {
+		t.Error(get)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	cases := map[string]string{
+		Bold("a*b"):                        `**a\*b**`,
+		Italic("a"):                        `*a*`,
+		BoldItalic("a"):                    `***a***`,
+		Strikethrough("a"):                 `~~a~~`,
+		Underline("a"):                     `(ins)a(ins)`,
+		Spoiler("a(spl)"):                  `(spl)a\(spl\)(spl)`,
+		Color("a", ThemeDanger):            `(font)a(font)[danger]`,
+		Link("[x]", "https://a.b/(c) d"):   `[\[x\]](https://a.b/%28c%29%20d)`,
+		Code("a`b"):                        "`a'b`",
+		CodeBlock("go", "```"):             "```go\n`\u200b``\n```\n",
+		Quote("a"):                         "> a\n\n",
+		MentionUser("123"):                 `(met)123(met)`,
+		MentionAll():                       `(met)all(met)`,
+		MentionHere():                      `(met)here(met)`,
+		MentionRole("1"):                   `(rol)1(rol)`,
+		Channel("2"):                       `(chn)2(chn)`,
+		Emoji("smile"):                     `:smile:`,
+		GuildEmoji("kook", "1234/abcdefg"): `(emj)kook(emj)[1234/abcdefg]`,
+	}
+	for get, expected := range cases {
+		if get != expected {
+			t.Error(get, expected)
+		}
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	get := NewBuilder().
+		MentionUser("1").Text(" rolled ").Bold("6").
+		Quote("lucky :)").
+		Text("done").
+		CodeBlock("", "x").
+		String()
+	expected := "(met)1(met) rolled **6**\n> lucky \\:\\)\n\ndone\n```\nx\n```\n"
+	if get != expected {
+		t.Error(get)
+	}
+}
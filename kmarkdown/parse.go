@@ -0,0 +1,365 @@
+package kmarkdown
+
+import (
+	"strings"
+)
+
+// NodeType is the type for types of Node.
+type NodeType int
+
+// These are types of nodes parsed from KMarkdown.
+const (
+	// NodeDocument is the root node.
+	NodeDocument NodeType = iota
+	// NodeText is plain text in Text, with escapes removed.
+	NodeText
+	NodeBold
+	NodeItalic
+	NodeStrikethrough
+	NodeUnderline
+	NodeSpoiler
+	// NodeColor has the theme in Attr.
+	NodeColor
+	// NodeLink has the url in Attr.
+	NodeLink
+	// NodeCode has the code in Text.
+	NodeCode
+	// NodeCodeBlock has the code in Text and the language in Attr.
+	NodeCodeBlock
+	NodeQuote
+	NodeDivider
+	// NodeMentionUser has the user id in Attr, which is "all" or "here" for mentions of all or online users.
+	NodeMentionUser
+	// NodeMentionRole has the role id in Attr.
+	NodeMentionRole
+	// NodeChannel has the channel id in Attr.
+	NodeChannel
+	// NodeEmoji has the short code in Text.
+	NodeEmoji
+	// NodeGuildEmoji has the name in Text and the emoji id in Attr.
+	NodeGuildEmoji
+)
+
+// Node is a node of the syntax tree of KMarkdown. Formatting nodes have their content in Children.
+type Node struct {
+	Type     NodeType
+	Text     string
+	Attr     string
+	Children []*Node
+}
+
+// Parse parses KMarkdown, e.g. KmarkdownMessageContext.Extra.Kmarkdown.RawContent, into a syntax tree.
+// Parsing never fails, unmatched markers are kept as text.
+func Parse(kmarkdown string) *Node {
+	return &Node{Type: NodeDocument, Children: (&parser{}).parse(kmarkdown, true)}
+}
+
+type parser struct {
+	nodes []*Node
+	text  strings.Builder
+}
+
+func (p *parser) flush() {
+	if p.text.Len() != 0 {
+		p.nodes = append(p.nodes, &Node{Type: NodeText, Text: p.text.String()})
+		p.text.Reset()
+	}
+}
+
+func (p *parser) add(n *Node) {
+	p.flush()
+	p.nodes = append(p.nodes, n)
+}
+
+// parse parses s into nodes. Blocks like quotes and dividers are only parsed at top level.
+func (p *parser) parse(s string, top bool) []*Node {
+	for i := 0; i < len(s); {
+		if top && (i == 0 || s[i-1] == '\n') {
+			if n := p.block(s[i:]); n > 0 {
+				i += n
+				continue
+			}
+		}
+		if n := p.inline(s[i:]); n > 0 {
+			i += n
+			continue
+		}
+		if s[i] == '\\' && i+1 < len(s) {
+			p.text.WriteByte(s[i+1])
+			i += 2
+			continue
+		}
+		p.text.WriteByte(s[i])
+		i++
+	}
+	p.flush()
+	return p.nodes
+}
+
+func parseInline(s string) []*Node {
+	return (&parser{}).parse(s, false)
+}
+
+// block parses a divider or a quote at the start of s, returning the bytes consumed.
+func (p *parser) block(s string) int {
+	line := s
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		line = s[:i]
+	}
+	if strings.TrimSpace(line) == "---" {
+		p.add(&Node{Type: NodeDivider})
+		if len(line) < len(s) {
+			return len(line) + 1
+		}
+		return len(line)
+	}
+	if strings.HasPrefix(s, ">") {
+		end := strings.Index(s, "\n\n")
+		n := end + 2
+		if end < 0 {
+			end, n = len(s), len(s)
+		}
+		content := strings.TrimPrefix(strings.TrimPrefix(s[:end], ">"), " ")
+		p.add(&Node{Type: NodeQuote, Children: parseInline(content)})
+		return n
+	}
+	return 0
+}
+
+var wrapping = []struct {
+	open, close string
+	typ         NodeType
+}{
+	{"(ins)", "(ins)", NodeUnderline},
+	{"(spl)", "(spl)", NodeSpoiler},
+	{"***", "***", NodeBold},
+	{"**", "**", NodeBold},
+	{"*", "*", NodeItalic},
+	{"~~", "~~", NodeStrikethrough},
+}
+
+var tokens = []struct {
+	tag string
+	typ NodeType
+}{
+	{"(met)", NodeMentionUser},
+	{"(rol)", NodeMentionRole},
+	{"(chn)", NodeChannel},
+}
+
+// inline parses an inline element at the start of s, returning the bytes consumed.
+func (p *parser) inline(s string) int {
+	switch {
+	case strings.HasPrefix(s, "```"):
+		end := strings.Index(s[3:], "```")
+		if end < 0 {
+			return 0
+		}
+		code := s[3 : 3+end]
+		lang := ""
+		if i := strings.IndexByte(code, '\n'); i >= 0 {
+			lang, code = strings.TrimSpace(code[:i]), code[i+1:]
+		}
+		p.add(&Node{Type: NodeCodeBlock, Text: strings.TrimSuffix(code, "\n"), Attr: lang})
+		return end + 6
+	case s[0] == '`':
+		end := strings.IndexByte(s[1:], '`')
+		if end < 0 {
+			return 0
+		}
+		p.add(&Node{Type: NodeCode, Text: s[1 : 1+end]})
+		return end + 2
+	case strings.HasPrefix(s, "(font)"):
+		end := findClose(s[6:], "(font)")
+		if end < 0 {
+			return 0
+		}
+		rest := s[6+end+6:]
+		theme, n := bracket(rest)
+		if n == 0 {
+			return 0
+		}
+		p.add(&Node{Type: NodeColor, Attr: theme, Children: parseInline(s[6 : 6+end])})
+		return 6 + end + 6 + n
+	case strings.HasPrefix(s, "(emj)"):
+		end := strings.Index(s[5:], "(emj)")
+		if end < 0 {
+			return 0
+		}
+		id, n := bracket(s[5+end+5:])
+		if n == 0 {
+			return 0
+		}
+		p.add(&Node{Type: NodeGuildEmoji, Text: s[5 : 5+end], Attr: id})
+		return 5 + end + 5 + n
+	case s[0] == '[':
+		return p.link(s)
+	case s[0] == ':':
+		return p.emoji(s)
+	}
+	for _, t := range tokens {
+		if strings.HasPrefix(s, t.tag) {
+			end := strings.Index(s[len(t.tag):], t.tag)
+			if end < 0 {
+				return 0
+			}
+			p.add(&Node{Type: t.typ, Attr: s[len(t.tag) : len(t.tag)+end]})
+			return end + 2*len(t.tag)
+		}
+	}
+	for _, w := range wrapping {
+		if strings.HasPrefix(s, w.open) {
+			var end int
+			if w.open[0] == '(' {
+				end = findClose(s[len(w.open):], w.close)
+			} else {
+				end = findEmphasisClose(s[len(w.open):], w.close)
+			}
+			if end <= 0 {
+				continue
+			}
+			children := parseInline(s[len(w.open) : len(w.open)+end])
+			if w.open == "***" {
+				children = []*Node{{Type: NodeItalic, Children: children}}
+			}
+			p.add(&Node{Type: w.typ, Children: children})
+			return len(w.open) + end + len(w.close)
+		}
+	}
+	return 0
+}
+
+func (p *parser) link(s string) int {
+	end := findClose(s[1:], "](")
+	if end < 0 {
+		return 0
+	}
+	rest := s[1+end+2:]
+	urlEnd := strings.IndexByte(rest, ')')
+	if urlEnd <= 0 || strings.ContainsAny(rest[:urlEnd], " \t\n") {
+		return 0
+	}
+	p.add(&Node{Type: NodeLink, Attr: rest[:urlEnd], Children: parseInline(s[1 : 1+end])})
+	return 1 + end + 2 + urlEnd + 1
+}
+
+func (p *parser) emoji(s string) int {
+	end := strings.IndexByte(s[1:], ':')
+	if end <= 0 {
+		return 0
+	}
+	name := s[1 : 1+end]
+	letter := false
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+			letter = true
+		case r >= '0' && r <= '9', r == '_', r == '-', r == '+':
+		default:
+			return 0
+		}
+	}
+	if !letter {
+		return 0
+	}
+	p.add(&Node{Type: NodeEmoji, Text: name})
+	return end + 2
+}
+
+// bracket parses "[content]" at the start of s.
+func bracket(s string) (content string, n int) {
+	if !strings.HasPrefix(s, "[") {
+		return "", 0
+	}
+	end := strings.IndexByte(s, ']')
+	if end < 0 {
+		return "", 0
+	}
+	return s[1:end], end + 1
+}
+
+// findClose returns the index of the unescaped delim in s, or -1.
+func findClose(s, delim string) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(s[i:], delim) {
+			return i
+		}
+	}
+	return -1
+}
+
+// findEmphasisClose returns the index of the delim closing emphasis in s, or -1. Like markdown, the content
+// should neither start nor end with spaces, and the delim closes at the end of a run of the same character,
+// e.g. "**" in "*b***" closes after the italic.
+func findEmphasisClose(s, delim string) int {
+	if s == "" || isSpace(s[0]) {
+		return -1
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if i == 0 || !strings.HasPrefix(s[i:], delim) || isSpace(s[i-1]) {
+			continue
+		}
+		run := i
+		for run < len(s) && s[run] == delim[0] {
+			run++
+		}
+		return run - len(delim)
+	}
+	return -1
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+// PlainText renders the node as plain text without formatting. Mentions are rendered as "@" followed by
+// the id, use PlainTextWith to render them with names.
+func (n *Node) PlainText() string {
+	return n.PlainTextWith(nil)
+}
+
+// PlainTextWith renders the node as plain text, with nodes rendered by resolve if it returns true,
+// e.g. to replace mentions with names from KmarkdownMessageContext.Extra.Kmarkdown.MentionPart.
+func (n *Node) PlainTextWith(resolve func(n *Node) (string, bool)) string {
+	b := &strings.Builder{}
+	n.plainText(b, resolve)
+	return b.String()
+}
+
+func (n *Node) plainText(b *strings.Builder, resolve func(n *Node) (string, bool)) {
+	if resolve != nil {
+		if s, ok := resolve(n); ok {
+			b.WriteString(s)
+			return
+		}
+	}
+	switch n.Type {
+	case NodeText, NodeCode, NodeCodeBlock:
+		b.WriteString(n.Text)
+	case NodeDivider:
+		b.WriteString("\n")
+	case NodeMentionUser, NodeMentionRole:
+		b.WriteString("@" + n.Attr)
+	case NodeChannel:
+		b.WriteString("#" + n.Attr)
+	case NodeEmoji, NodeGuildEmoji:
+		b.WriteString(":" + n.Text + ":")
+	case NodeQuote:
+		for _, c := range n.Children {
+			c.plainText(b, resolve)
+		}
+		b.WriteString("\n")
+	default:
+		for _, c := range n.Children {
+			c.plainText(b, resolve)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package kmarkdown
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	n := Parse("(met)123(met) **hi *there*** \\*x\\* [site](https://a.b) (rol)4(rol)(chn)5(chn) :smile: 12:30:00\n" +
+		"> quoted (spl)secret(spl)\n\n" +
+		"---\n" +
+		"(font)red(font)[danger] (emj)kook(emj)[1/abc] `a*b` ~~gone~~ (ins)u(ins)\n" +
+		"```go\nfmt.Println(\"**\")\n```")
+	types := []NodeType{}
+	for _, c := range n.Children {
+		types = append(types, c.Type)
+	}
+	expected := []NodeType{
+		NodeMentionUser, NodeText, NodeBold, NodeText, NodeLink, NodeText, NodeMentionRole, NodeChannel, NodeText,
+		NodeEmoji, NodeText, NodeQuote, NodeDivider, NodeColor, NodeText, NodeGuildEmoji, NodeText, NodeCode,
+		NodeText, NodeStrikethrough, NodeText, NodeUnderline, NodeText, NodeCodeBlock,
+	}
+	if len(types) != len(expected) {
+		t.Fatal(types)
+	}
+	for i := range types {
+		if types[i] != expected[i] {
+			t.Error(i, types[i], expected[i])
+		}
+	}
+	c := n.Children
+	if c[0].Attr != "123" || c[4].Attr != "https://a.b" || c[13].Attr != "danger" || c[15].Attr != "1/abc" {
+		t.Error(c[0], c[4], c[13], c[15])
+	}
+	if get := c[3].Text; get != " *x* " {
+		t.Error(get)
+	}
+	if get := c[2].Children[1].Type; get != NodeItalic {
+		t.Error(get)
+	}
+	if get := c[23]; get.Attr != "go" || get.Text != `fmt.Println("**")` {
+		t.Error(get)
+	}
+
+	get := n.PlainText()
+	expectedText := "@123 hi there *x* site @4#5 :smile: 12:30:00\nquoted secret\n\nred :kook: a*b gone u\nfmt.Println(\"**\")"
+	if get != expectedText {
+		t.Error(get)
+	}
+	get = Parse("(met)123(met) hi").PlainTextWith(func(n *Node) (string, bool) {
+		if n.Type == NodeMentionUser {
+			return "@kook", true
+		}
+		return "", false
+	})
+	if get != "@kook hi" {
+		t.Error(get)
+	}
+}
+
+func TestParse_Unmatched(t *testing.T) {
+	s := "**a *b [c]( `d (met)e"
+	if get := Parse(s).PlainText(); get != s {
+		t.Error(get)
+	}
+	if get := Parse(Escape(s)).PlainText(); get != s {
+		t.Error(get)
+	}
+}
+
+func TestParse_Escaped(t *testing.T) {
+	n := Parse(Italic("*x"))
+	if len(n.Children) != 1 || n.Children[0].Type != NodeItalic {
+		t.Fatal(n.Children)
+	}
+	if get := n.PlainText(); get != "*x" {
+		t.Error(get)
+	}
+}
@@ -0,0 +1,177 @@
+package kook
+
+import (
+	"net/url"
+	"sync/atomic"
+	"time"
+)
+
+// SessionState is the type for the connection state of a session.
+type SessionState int
+
+// These are all session states.
+const (
+	SessionStateDisconnected SessionState = iota
+	SessionStateConnecting
+	SessionStateConnected
+	SessionStateReconnecting
+	SessionStateShutdown
+)
+
+// String returns the name of the state.
+func (s SessionState) String() string {
+	switch s {
+	case SessionStateDisconnected:
+		return "disconnected"
+	case SessionStateConnecting:
+		return "connecting"
+	case SessionStateConnected:
+		return "connected"
+	case SessionStateReconnecting:
+		return "reconnecting"
+	case SessionStateShutdown:
+		return "shutdown"
+	default:
+		return "unknown"
+	}
+}
+
+// LifecycleEvent is the type for connection lifecycle events.
+type LifecycleEvent int
+
+// These are all lifecycle events.
+const (
+	LifecycleConnected LifecycleEvent = 1 + iota
+	LifecycleDisconnected
+	LifecycleReconnecting
+	LifecycleResumed
+	LifecycleResumeFailed
+	LifecycleGaveUp
+)
+
+// String returns the name of the lifecycle event.
+func (e LifecycleEvent) String() string {
+	switch e {
+	case LifecycleConnected:
+		return "connected"
+	case LifecycleDisconnected:
+		return "disconnected"
+	case LifecycleReconnecting:
+		return "reconnecting"
+	case LifecycleResumed:
+		return "resumed"
+	case LifecycleResumeFailed:
+		return "resume_failed"
+	case LifecycleGaveUp:
+		return "gave_up"
+	default:
+		return "unknown"
+	}
+}
+
+// LifecycleContext is the context passed to lifecycle handlers.
+type LifecycleContext struct {
+	Session *Session
+	Event   LifecycleEvent
+	// Attempt is the number of the reconnect attempt starting from 1, only set for LifecycleReconnecting.
+	Attempt int
+	// Err is the cause of LifecycleDisconnected and LifecycleResumeFailed,
+	// or the error of the last attempt for LifecycleReconnecting and LifecycleGaveUp.
+	// Refusals of the gateway are *GatewayError.
+	Err error
+}
+
+// LifecycleHandler is the type for functions handling lifecycle events.
+//
+// Handlers are called synchronously from the connection goroutines, so they should return quickly.
+type LifecycleHandler func(*LifecycleContext)
+
+type lifecycleHandlerInstance struct {
+	handler LifecycleHandler
+}
+
+// AddLifecycleHandler adds a handler for connection lifecycle events, and returns the function to remove it.
+func (s *Session) AddLifecycleHandler(h LifecycleHandler) func() {
+	s.handlersMu.Lock()
+	defer s.handlersMu.Unlock()
+	lhi := &lifecycleHandlerInstance{handler: h}
+	s.lifecycleHandlers = append(s.lifecycleHandlers, lhi)
+	return func() {
+		s.handlersMu.Lock()
+		defer s.handlersMu.Unlock()
+		for i := range s.lifecycleHandlers {
+			if s.lifecycleHandlers[i] == lhi {
+				s.lifecycleHandlers = append(s.lifecycleHandlers[:i], s.lifecycleHandlers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// emitLifecycle calls lifecycle handlers, it must not be called with the session locked.
+func (s *Session) emitLifecycle(e LifecycleEvent, attempt int, err error) {
+	e2 := addCaller(s.Logger.Info()).Str("event", e.String())
+	if attempt != 0 {
+		e2 = e2.Int("attempt", attempt)
+	}
+	if err != nil {
+		e2 = e2.Err("err", err)
+	}
+	e2.Msg("lifecycle event")
+	s.handlersMu.RLock()
+	handlers := make([]*lifecycleHandlerInstance, len(s.lifecycleHandlers))
+	copy(handlers, s.lifecycleHandlers)
+	s.handlersMu.RUnlock()
+	for _, item := range handlers {
+		item.handler(&LifecycleContext{
+			Session: s,
+			Event:   e,
+			Attempt: attempt,
+			Err:     err,
+		})
+	}
+}
+
+// Status is the snapshot of the connection status of a session.
+type Status struct {
+	State SessionState `json:"state"`
+	// Latency is the round trip time of the last acknowledged heartbeat.
+	Latency   time.Duration  `json:"latency"`
+	Heartbeat HeartbeatStats `json:"heartbeat"`
+	// Gateway is the gateway url without query, as the query contains credentials.
+	Gateway           string    `json:"gateway"`
+	SessionID         string    `json:"session_id"`
+	SequenceNumber    int64     `json:"sn"`
+	ReconnectAttempt  int       `json:"reconnect_attempt"`
+	LastHeartbeatSent time.Time `json:"last_heartbeat_sent"`
+	LastHeartbeatAck  time.Time `json:"last_heartbeat_ack"`
+}
+
+// Ready reports whether the session is connected, which is suitable for a readiness probe.
+func (s Status) Ready() bool {
+	return s.State == SessionStateConnected
+}
+
+// Status returns the current connection status of the session.
+func (s *Session) Status() Status {
+	s.RLock()
+	defer s.RUnlock()
+	st := Status{
+		State:             s.state,
+		SessionID:         s.sessionID,
+		SequenceNumber:    atomic.LoadInt64(s.sequence),
+		ReconnectAttempt:  s.reconnectAttempt,
+		LastHeartbeatSent: s.LastHeartbeatSent,
+		LastHeartbeatAck:  s.LastHeartbeatAck,
+	}
+	if s.isShutdown() {
+		st.State = SessionStateShutdown
+	}
+	st.Heartbeat = s.latency.stats()
+	st.Latency = st.Heartbeat.Last
+	if u, err := url.Parse(s.gateway); err == nil && s.gateway != "" {
+		u.RawQuery = ""
+		st.Gateway = u.String()
+	}
+	return st
+}
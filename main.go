@@ -2,21 +2,33 @@ package kook
 
 import (
 	"bytes"
+	"crypto/tls"
 	"net/http"
+	"net/url"
 	"time"
+
+	"github.com/gorilla/websocket"
 )
 
 // New creates a kook session with default settings
 func New(token string, l Logger, o ...SessionOption) (s *Session) {
 	s = &Session{
-		Client:       &http.Client{Timeout: 30 * time.Second},
-		sequence:     new(int64),
-		MaxRetry:     3,
-		RetryTimeout: 60 * time.Second,
-		ContentType:  "application/json",
-		Logger:       l,
-		snStore:      newBloomSnStore(),
-		Sync:         true,
+		Client:          &http.Client{Timeout: 30 * time.Second},
+		Dialer:          newDefaultDialer(),
+		RateLimiter:     NewRateLimiter(),
+		ReconnectPolicy: NewExponentialBackoff(),
+		sequence:        new(int64),
+		MaxRetry:        3,
+		RetryTimeout:    60 * time.Second,
+		ContentType:     "application/json",
+		Logger:          l,
+		snStore:         newBloomSnStore(),
+		Sync:            true,
+		endpointAPI:     EndpointAPI,
+
+		heartbeatInterval: defaultHeartbeatInterval,
+		heartbeatTimeout:  defaultHeartbeatTimeout,
+		heartbeatJitter:   defaultHeartbeatJitter,
 	}
 	s.Identify.Token = "Bot " + token
 	s.Identify.Compress = true
@@ -27,8 +39,47 @@ func New(token string, l Logger, o ...SessionOption) (s *Session) {
 }
 
 // SessionOption is the optional arguments for creating a session.
+//
+// Options are applied in order, so a later SessionWithDialer replaces the settings made to the dialer by earlier options.
 type SessionOption func(*Session)
 
+func newDefaultDialer() *websocket.Dialer {
+	d := *websocket.DefaultDialer
+	return &d
+}
+
+// ownClient makes sure the session has a http client which is not shared with other sessions.
+func (s *Session) ownClient() {
+	if s.Client == nil {
+		s.Client = &http.Client{Timeout: 30 * time.Second}
+		s.clientShared = false
+	}
+	if s.clientShared {
+		c := *s.Client
+		if t, ok := c.Transport.(*http.Transport); ok {
+			c.Transport = t.Clone()
+		}
+		s.Client = &c
+		s.clientShared = false
+	}
+}
+
+// transport returns the http transport of the session client, replacing a shared or missing one with a private clone.
+//
+// It returns nil if the client uses a custom RoundTripper, which could not be configured.
+func (s *Session) transport() *http.Transport {
+	s.ownClient()
+	if s.Client.Transport == nil || s.Client.Transport == http.DefaultTransport {
+		s.Client.Transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	t, ok := s.Client.Transport.(*http.Transport)
+	if !ok {
+		addCaller(s.Logger.Warn()).Msg("custom http round tripper, transport settings ignored")
+		return nil
+	}
+	return t
+}
+
 // SessionWithVerifyToken adds the token for verifying webhook request.
 func SessionWithVerifyToken(token string) SessionOption {
 	return func(session *Session) {
@@ -51,3 +102,112 @@ func SessionWithEncryptKey(key []byte) SessionOption {
 		session.Identify.WebsocketKey = key
 	}
 }
+
+// SessionWithAPIBase changes the base url of http api for the session, e.g. a test server or a proxy.
+//
+// The base has the same meaning as EndpointBase, so APIVersion is appended to it.
+func SessionWithAPIBase(base string) SessionOption {
+	return func(session *Session) {
+		if _, err := url.Parse(base); err != nil {
+			addCaller(session.Logger.Error()).Str("api_base", base).Err("err", err).Msg("invalid api base, ignored")
+			return
+		}
+		session.endpointAPI = urlJoin(base, APIVersion)
+	}
+}
+
+// SessionWithDialer replaces the dialer for websocket connection. A nil dialer restores the default one.
+//
+// The dialer is copied before it is changed by later options, so websocket.DefaultDialer or a dialer
+// shared with others stays untouched.
+func SessionWithDialer(d *websocket.Dialer) SessionOption {
+	return func(session *Session) {
+		if d == nil {
+			session.Dialer = newDefaultDialer()
+			session.dialerShared = false
+			return
+		}
+		session.Dialer = d
+		session.dialerShared = true
+	}
+}
+
+// ownDialer makes sure the session has a websocket dialer which is not shared with others.
+func (s *Session) ownDialer() *websocket.Dialer {
+	if s.Dialer == nil {
+		s.Dialer = newDefaultDialer()
+		s.dialerShared = false
+	}
+	if s.dialerShared {
+		d := *s.Dialer
+		s.Dialer = &d
+		s.dialerShared = false
+	}
+	return s.Dialer
+}
+
+// SessionWithProxy routes both websocket and http api traffic through a proxy.
+//
+// Schemes supported by net/http and gorilla/websocket are accepted, e.g. http, https and socks5.
+func SessionWithProxy(proxy *url.URL) SessionOption {
+	return func(session *Session) {
+		session.ownDialer().Proxy = http.ProxyURL(proxy)
+		if t := session.transport(); t != nil {
+			t.Proxy = http.ProxyURL(proxy)
+		}
+	}
+}
+
+// SessionWithTLSConfig sets the tls config for both websocket and http api connection.
+func SessionWithTLSConfig(c *tls.Config) SessionOption {
+	return func(session *Session) {
+		session.ownDialer().TLSClientConfig = c
+		if t := session.transport(); t != nil {
+			t.TLSClientConfig = c
+		}
+	}
+}
+
+// SessionWithHandshakeTimeout sets the timeout of websocket handshake and tls handshake of http api.
+func SessionWithHandshakeTimeout(d time.Duration) SessionOption {
+	return func(session *Session) {
+		session.ownDialer().HandshakeTimeout = d
+		if t := session.transport(); t != nil {
+			t.TLSHandshakeTimeout = d
+		}
+	}
+}
+
+// SessionWithRequestTimeout sets the timeout of a whole http api request.
+func SessionWithRequestTimeout(d time.Duration) SessionOption {
+	return func(session *Session) {
+		session.ownClient()
+		session.Client.Timeout = d
+	}
+}
+
+// SessionWithReadLimit sets the maximum size in bytes of a websocket message or a http api response.
+func SessionWithReadLimit(limit int64) SessionOption {
+	return func(session *Session) {
+		session.ReadLimit = limit
+	}
+}
+
+// SessionWithHeader adds custom headers to websocket handshake and http api requests.
+func SessionWithHeader(h http.Header) SessionOption {
+	return func(session *Session) {
+		if session.Header == nil {
+			session.Header = http.Header{}
+		}
+		for k, v := range h {
+			session.Header[k] = append(session.Header[k], v...)
+		}
+	}
+}
+
+// SessionWithCompress toggles the zlib compression of websocket messages.
+func SessionWithCompress(compress bool) SessionOption {
+	return func(session *Session) {
+		session.Identify.Compress = compress
+	}
+}
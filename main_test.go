@@ -0,0 +1,23 @@
+package kook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestSessionWithDialer(t *testing.T) {
+	timeout := websocket.DefaultDialer.HandshakeTimeout
+	s := New("", mockLogger{}, SessionWithDialer(websocket.DefaultDialer), SessionWithHandshakeTimeout(time.Second))
+	if websocket.DefaultDialer.HandshakeTimeout != timeout {
+		t.Error(websocket.DefaultDialer.HandshakeTimeout)
+	}
+	if s.Dialer == websocket.DefaultDialer || s.Dialer.HandshakeTimeout != time.Second {
+		t.Error(s.Dialer)
+	}
+	s = New("", mockLogger{}, SessionWithDialer(nil), SessionWithHandshakeTimeout(time.Second))
+	if s.Dialer == nil || s.Dialer.HandshakeTimeout != time.Second {
+		t.Error(s.Dialer)
+	}
+}
@@ -0,0 +1,239 @@
+package kook
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrSessionExists is the error when adding a session with a name already used in a manager.
+var ErrSessionExists = errors.New("session with the same name exists")
+
+// ErrSessionNotFound is the error when a session could not be found in a manager.
+var ErrSessionNotFound = errors.New("session not found")
+
+// ManagerError collects the errors of sessions from a manager operation, keyed by session name.
+type ManagerError map[string]error
+
+// Error provides the formatted error string
+func (m ManagerError) Error() string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	b := &strings.Builder{}
+	for i, name := range names {
+		if i != 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(name + ": " + m[name].Error())
+	}
+	return b.String()
+}
+
+// Manager runs sessions of multiple bots in one process.
+//
+// Sessions added to a manager share its http client and rate limiter, unless overridden by SessionOptions.
+type Manager struct {
+	sync.RWMutex
+
+	Logger      Logger
+	Client      *http.Client
+	RateLimiter *RateLimiter
+
+	sessions map[string]*Session
+}
+
+// NewManager creates a manager with a shared http client and rate limiter.
+func NewManager(l Logger) *Manager {
+	return &Manager{
+		Logger:      l,
+		Client:      &http.Client{Timeout: 30 * time.Second},
+		RateLimiter: NewRateLimiter(),
+		sessions:    map[string]*Session{},
+	}
+}
+
+// Add creates a session for the bot token under the name. The session is not opened.
+func (m *Manager) Add(name, token string, o ...SessionOption) (*Session, error) {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.sessions[name]; ok {
+		return nil, ErrSessionExists
+	}
+	shared := func(session *Session) {
+		session.Client = m.Client
+		session.clientShared = true
+		session.RateLimiter = m.RateLimiter
+	}
+	s := New(token, m.Logger, append([]SessionOption{shared}, o...)...)
+	m.sessions[name] = s
+	return s, nil
+}
+
+// Session returns the session with the name, or nil if not found.
+func (m *Manager) Session(name string) *Session {
+	m.RLock()
+	defer m.RUnlock()
+	return m.sessions[name]
+}
+
+// Names returns the sorted names of all sessions.
+func (m *Manager) Names() []string {
+	m.RLock()
+	defer m.RUnlock()
+	names := make([]string, 0, len(m.sessions))
+	for name := range m.sessions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Remove shuts down the session with the name and removes it from the manager.
+func (m *Manager) Remove(ctx context.Context, name string) error {
+	m.Lock()
+	s, ok := m.sessions[name]
+	delete(m.sessions, name)
+	m.Unlock()
+	if !ok {
+		return ErrSessionNotFound
+	}
+	return s.Shutdown(ctx)
+}
+
+// Open opens websocket connections of all sessions. Sessions serving webhook should not be opened.
+//
+// Failed sessions are reported in a ManagerError, and other sessions stay opened.
+func (m *Manager) Open() error {
+	return m.each(func(name string, s *Session) error {
+		return s.Open()
+	})
+}
+
+// Shutdown gracefully shuts down all sessions concurrently, see Session.Shutdown.
+func (m *Manager) Shutdown(ctx context.Context) error {
+	return m.each(func(name string, s *Session) error {
+		return s.Shutdown(ctx)
+	})
+}
+
+// Run opens all sessions and blocks until ctx is done, then shuts them down within the timeout.
+//
+// Combined with signal.NotifyContext, it replaces the signal handling boilerplate of every bot.
+func (m *Manager) Run(ctx context.Context, shutdownTimeout time.Duration) error {
+	if err := m.Open(); err != nil {
+		addCaller(m.Logger.Error()).Err("err", err).Msg("error opening sessions")
+	}
+	<-ctx.Done()
+	sctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return m.Shutdown(sctx)
+}
+
+// Status returns the status of all sessions keyed by name.
+func (m *Manager) Status() map[string]Status {
+	m.RLock()
+	defer m.RUnlock()
+	st := make(map[string]Status, len(m.sessions))
+	for name, s := range m.sessions {
+		st[name] = s.Status()
+	}
+	return st
+}
+
+// Ready reports whether all sessions are connected.
+func (m *Manager) Ready() bool {
+	for _, item := range m.Status() {
+		if !item.Ready() {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Manager) each(f func(name string, s *Session) error) error {
+	m.RLock()
+	sessions := make(map[string]*Session, len(m.sessions))
+	for name, s := range m.sessions {
+		sessions[name] = s
+	}
+	m.RUnlock()
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := ManagerError{}
+	for name, s := range sessions {
+		wg.Add(1)
+		go func(name string, s *Session) {
+			defer wg.Done()
+			if err := f(name, s); err != nil {
+				mu.Lock()
+				errs[name] = err
+				mu.Unlock()
+			}
+		}(name, s)
+	}
+	wg.Wait()
+	if len(errs) != 0 {
+		return errs
+	}
+	return nil
+}
+
+// WebhookHandler provides a http.HandlerFunc routing webhook requests to sessions.
+//
+// The session is chosen by the path after prefix, e.g. `/webhook/mybot` for prefix `/webhook/`.
+// If no session has the name, the request is routed to the session whose verify token matches the payload.
+func (m *Manager) WebhookHandler(prefix string) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		name := strings.Trim(strings.TrimPrefix(request.URL.Path, prefix), "/")
+		if s := m.Session(name); s != nil {
+			s.WebhookHandler()(writer, request)
+			return
+		}
+		defer request.Body.Close()
+		if request.Method != "POST" {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		raw, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			addCaller(m.Logger.Error().Err("error", err)).Msg("error in reading body")
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		compressed := !strings.Contains(request.RequestURI, "compress=0")
+		m.RLock()
+		sessions := make([]*Session, 0, len(m.sessions))
+		for _, s := range m.sessions {
+			if s.Identify.VerifyToken != "" {
+				sessions = append(sessions, s)
+			}
+		}
+		m.RUnlock()
+		for _, s := range sessions {
+			payload, msg, err := s.decodeWebhookPayload(raw, compressed)
+			if err != nil {
+				addCaller(s.Logger.Debug().Err("error", err)).Msg(msg)
+				continue
+			}
+			if webhookVerifyToken(payload) != s.Identify.VerifyToken {
+				continue
+			}
+			if s.isShutdown() {
+				writer.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			s.serveWebhook(writer, payload)
+			return
+		}
+		addCaller(m.Logger.Warn()).Str("path", request.URL.Path).Msg("no session for webhook request")
+		writer.WriteHeader(http.StatusNotFound)
+	}
+}
@@ -0,0 +1,110 @@
+package kook
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// BotID returns the id of the bot, which is requested by UserMe once and cached.
+func (s *Session) BotID() (string, error) {
+	s.botIDMu.Lock()
+	defer s.botIDMu.Unlock()
+	if s.botID != "" {
+		return s.botID, nil
+	}
+	u, err := s.UserMe()
+	if err != nil {
+		return "", err
+	}
+	s.botID = u.ID
+	return s.botID, nil
+}
+
+// MentionsUser reports whether the user is mentioned explicitly, not including MentionAll and MentionHere.
+func (m *EventCustomMessage) MentionsUser(userID string) bool {
+	for _, item := range m.Mention {
+		if item == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// MentionedUsers returns the users mentioned explicitly, in the order of Mention. Users missing in
+// Kmarkdown.MentionPart only have their ID set.
+func (m *EventCustomMessage) MentionedUsers() []EventMentionPart {
+	parts := make(map[string]EventMentionPart, len(m.Kmarkdown.MentionPart))
+	for _, item := range m.Kmarkdown.MentionPart {
+		parts[item.ID] = item
+	}
+	users := make([]EventMentionPart, 0, len(m.Mention))
+	for _, id := range m.Mention {
+		if id == "all" || id == "here" {
+			continue
+		}
+		u, ok := parts[id]
+		if !ok {
+			u = EventMentionPart{ID: id}
+		}
+		users = append(users, u)
+	}
+	return users
+}
+
+// MentionedRoles returns the roles mentioned, in the order of MentionRoles. Roles missing in
+// Kmarkdown.MentionRolePart only have their RoleID set.
+func (m *EventCustomMessage) MentionedRoles() []EventMentionRolePart {
+	parts := make(map[int64]EventMentionRolePart, len(m.Kmarkdown.MentionRolePart))
+	for _, item := range m.Kmarkdown.MentionRolePart {
+		parts[item.RoleID] = item
+	}
+	roles := make([]EventMentionRolePart, 0, len(m.MentionRoles))
+	for _, id := range m.MentionRoles {
+		r, ok := parts[id]
+		if !ok {
+			r = EventMentionRolePart{RoleID: id}
+		}
+		roles = append(roles, r)
+	}
+	return roles
+}
+
+// MentionsRole reports whether the role is mentioned.
+func (m *EventCustomMessage) MentionsRole(roleID string) bool {
+	for _, item := range m.MentionRoles {
+		if strconv.FormatInt(item, 10) == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// mentionsBot reports whether the bot of the session is mentioned explicitly.
+func (m *EventCustomMessage) mentionsBot(s *Session) bool {
+	if s == nil || len(m.Mention) == 0 {
+		return false
+	}
+	id, err := s.BotID()
+	if err != nil {
+		addCaller(s.Logger.Error()).Err("err", err).Msg("get bot id failed")
+		//s.log(LogError, "get bot id failed: %s", err)
+		return false
+	}
+	return m.MentionsUser(id)
+}
+
+var mentionPattern = regexp.MustCompile(`\((met|rol)\)[^()\s]*\((met|rol)\) *`)
+
+// StripMentions removes mentions of users and roles, e.g. "(met)123(met)", from KMarkdown content and
+// trims the spaces left, so commands after mentions could be parsed.
+func StripMentions(content string) string {
+	content = mentionPattern.ReplaceAllStringFunc(content, func(s string) string {
+		t := strings.TrimRight(s, " ")
+		if t[:5] != t[len(t)-5:] {
+			return s
+		}
+		return ""
+	})
+	return strings.TrimSpace(content)
+}
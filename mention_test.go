@@ -0,0 +1,50 @@
+package kook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTextMessageContext_Mentions(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/v3/user/me" {
+			t.Error(r.URL.Path)
+		}
+		w.Write([]byte(`{"code":0,"message":"","data":{"id":"100","username":"bot"}}`))
+	}))
+	defer ts.Close()
+	s := New("", mockLogger{}, SessionWithAPIBase(ts.URL))
+	ctx := &TextMessageContext{EventHandlerCommonContext: &EventHandlerCommonContext{
+		Session: s,
+		Common:  &EventDataGeneral{Content: "(met)100(met) (rol)7(rol) /roll  d6"},
+	}}
+	err := json.Unmarshal([]byte(`{"mention":[100,"200"],"mention_roles":[7],"kmarkdown":{`+
+		`"mention_part":[{"id":"200","username":"kook"}],"mention_role_part":[{"role_id":7,"name":"admin"}]}}`), &ctx.Extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ctx.MentionsBot() || !ctx.MentionsBot() || requests != 1 {
+		t.Error(requests)
+	}
+	if !ctx.MentionsUser("200") || ctx.MentionsUser("300") {
+		t.Error(ctx.Extra.Mention)
+	}
+	users := ctx.MentionedUsers()
+	if len(users) != 2 || users[0].ID != "100" || users[1].Username != "kook" {
+		t.Error(users)
+	}
+	roles := ctx.MentionedRoles()
+	if len(roles) != 1 || roles[0].Name != "admin" || !ctx.Extra.MentionsRole("7") {
+		t.Error(roles)
+	}
+	if get := ctx.StripMentions(); get != "/roll  d6" {
+		t.Error(get)
+	}
+	if get := StripMentions("hi (met)1(met) there (met)all(met)"); get != "hi there" {
+		t.Error(get)
+	}
+}
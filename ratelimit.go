@@ -0,0 +1,219 @@
+package kook
+
+import (
+	"context"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter tracks the rate limit buckets reported by kook in http api responses,
+// and delays requests which would exceed the limits.
+//
+// FYI: https://developer.kookapp.cn/doc/rate-limit
+//
+// A RateLimiter could be shared by sessions of different bots, as buckets are kept per token
+// while the global limit is shared. Endpoints reported in the same bucket by X-Rate-Limit-Bucket share
+// one Bucket after their first responses.
+type RateLimiter struct {
+	sync.Mutex
+	buckets map[string]*Bucket
+	routes  map[string]string
+	global  time.Time
+}
+
+// NewRateLimiter creates a rate limiter.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		buckets: map[string]*Bucket{},
+		routes:  map[string]string{},
+	}
+}
+
+// Bucket is a rate limit bucket. It is only locked while a request is reserved or a response is recorded,
+// so requests in the same bucket are sent concurrently as long as the bucket has remaining requests.
+type Bucket struct {
+	sync.Mutex
+	Key       string
+	Remaining int
+	Reset     time.Time
+
+	limit   int
+	limiter *RateLimiter
+}
+
+// RateLimitInfo is the rate limit status reported in a http api response.
+type RateLimitInfo struct {
+	Bucket    string        `json:"bucket"`
+	Limit     int           `json:"limit"`
+	Remaining int           `json:"remaining"`
+	Reset     time.Duration `json:"reset"`
+	Global    bool          `json:"global"`
+}
+
+// parseRateLimitInfo reads rate limit headers, it returns nil if there is no such header.
+func parseRateLimitInfo(h http.Header) *RateLimitInfo {
+	if h == nil || h.Get("X-Rate-Limit-Remaining") == "" {
+		return nil
+	}
+	r := &RateLimitInfo{
+		Bucket: h.Get("X-Rate-Limit-Bucket"),
+		Global: h.Get("X-Rate-Limit-Global") != "",
+	}
+	r.Limit, _ = strconv.Atoi(h.Get("X-Rate-Limit-Limit"))
+	r.Remaining, _ = strconv.Atoi(h.Get("X-Rate-Limit-Remaining"))
+	reset, _ := strconv.ParseFloat(h.Get("X-Rate-Limit-Reset"), 64)
+	r.Reset = time.Duration(reset * float64(time.Second))
+	return r
+}
+
+// GetBucket returns the bucket for the key, creating it if it does not exist.
+// Keys of endpoints are resolved to the bucket reported by kook.
+func (r *RateLimiter) GetBucket(key string) *Bucket {
+	r.Lock()
+	defer r.Unlock()
+	if shared, ok := r.routes[key]; ok {
+		key = shared
+	}
+	return r.getBucketLocked(key)
+}
+
+func (r *RateLimiter) getBucketLocked(key string) *Bucket {
+	if b, ok := r.buckets[key]; ok {
+		return b
+	}
+	b := &Bucket{
+		Key:       key,
+		Remaining: 1,
+		limiter:   r,
+	}
+	r.buckets[key] = b
+	return b
+}
+
+// sharedBucket returns the bucket named by kook for the key, and remembers it for later requests.
+func (r *RateLimiter) sharedBucket(key, name string) *Bucket {
+	r.Lock()
+	defer r.Unlock()
+	prefix := key
+	if i := strings.IndexAny(key, ":#"); i >= 0 {
+		prefix = key[:i]
+	}
+	shared := prefix + "#" + name
+	if shared != key {
+		r.routes[key] = shared
+	}
+	return r.getBucketLocked(shared)
+}
+
+// GetWaitTime returns the time to wait before the bucket accepts a new request.
+func (r *RateLimiter) GetWaitTime(b *Bucket) time.Duration {
+	r.Lock()
+	global := r.global
+	r.Unlock()
+	now := time.Now()
+	if now.Before(global) {
+		return global.Sub(now)
+	}
+	if b.Remaining < 1 && now.Before(b.Reset) {
+		return b.Reset.Sub(now)
+	}
+	return 0
+}
+
+// reserve waits until the bucket for the key accepts a new request, and takes it if consume is true.
+// The bucket is not locked while waiting, so responses of other requests could still be recorded.
+func (r *RateLimiter) reserve(ctx context.Context, key string, consume bool) (*Bucket, error) {
+	for {
+		b := r.GetBucket(key)
+		b.Lock()
+		if b.Remaining < 1 && b.limit > 0 && !time.Now().Before(b.Reset) {
+			b.Remaining = b.limit
+		}
+		wait := r.GetWaitTime(b)
+		if wait <= 0 {
+			if consume {
+				b.Remaining--
+			}
+			b.Unlock()
+			return b, nil
+		}
+		b.Unlock()
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// LockBucket waits until the bucket for the key accepts a new request and takes it.
+// The bucket is not kept locked, Release it with the headers of the response.
+func (r *RateLimiter) LockBucket(key string) *Bucket {
+	b, _ := r.LockBucketContext(context.Background(), key)
+	return b
+}
+
+// LockBucketContext is like LockBucket, but stops waiting when ctx is done.
+func (r *RateLimiter) LockBucketContext(ctx context.Context, key string) (*Bucket, error) {
+	return r.reserve(ctx, key, true)
+}
+
+// Wait waits until the bucket for the key accepts a new request without consuming it.
+func (r *RateLimiter) Wait(ctx context.Context, key string) error {
+	_, err := r.reserve(ctx, key, false)
+	return err
+}
+
+// Release updates the bucket with the rate limit headers of a response. If kook reports the bucket by
+// X-Rate-Limit-Bucket, the shared bucket is updated, and used for later requests with the same key.
+func (b *Bucket) Release(h http.Header) {
+	info := parseRateLimitInfo(h)
+	if info == nil {
+		return
+	}
+	if info.Bucket != "" {
+		b = b.limiter.sharedBucket(b.Key, info.Bucket)
+	}
+	reset := time.Now().Add(info.Reset)
+	b.Lock()
+	defer b.Unlock()
+	b.Remaining = info.Remaining
+	b.limit = info.Limit
+	b.Reset = reset
+	if info.Global {
+		b.limiter.Lock()
+		if reset.After(b.limiter.global) {
+			b.limiter.global = reset
+		}
+		b.limiter.Unlock()
+	}
+}
+
+// SessionWithRateLimiter replaces the rate limiter of the session, e.g. to share one among sessions.
+func SessionWithRateLimiter(r *RateLimiter) SessionOption {
+	return func(session *Session) {
+		session.RateLimiter = r
+	}
+}
+
+// rateLimitKey returns the bucket key of an endpoint, which is the path under EndpointAPI for the token.
+func (s *Session) rateLimitKey(endpoint string) string {
+	u, err := url.Parse(s.endpoint(endpoint))
+	p := endpoint
+	if err == nil {
+		p = u.Path
+		if a, err := url.Parse(s.endpointAPI); err == nil {
+			p = strings.TrimPrefix(p, a.Path)
+		}
+	}
+	h := fnv.New64a()
+	h.Write([]byte(s.Identify.Token))
+	return strconv.FormatUint(h.Sum64(), 16) + ":" + strings.Trim(p, "/")
+}
@@ -0,0 +1,57 @@
+package kook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_SharedBucket(t *testing.T) {
+	r := NewRateLimiter()
+	h := http.Header{}
+	h.Set("X-Rate-Limit-Bucket", "message/create")
+	h.Set("X-Rate-Limit-Limit", "5")
+	h.Set("X-Rate-Limit-Remaining", "0")
+	h.Set("X-Rate-Limit-Reset", "1")
+	r.LockBucket("t:message/create").Release(h)
+	r.LockBucket("t:message/update").Release(h)
+	b := r.GetBucket("t:message/create")
+	if b != r.GetBucket("t:message/update") || b.Key != "t#message/create" {
+		t.Error(b.Key)
+	}
+	if wait := r.GetWaitTime(b); wait <= 0 || wait > time.Second {
+		t.Error(wait)
+	}
+	if r.GetBucket("u:message/create") == b {
+		t.Error("bucket shared between tokens")
+	}
+}
+
+func TestSession_RequestConcurrent(t *testing.T) {
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived.Done()
+		arrived.Wait()
+		w.Header().Set("X-Rate-Limit-Limit", "10")
+		w.Header().Set("X-Rate-Limit-Remaining", "8")
+		w.Header().Set("X-Rate-Limit-Reset", "1")
+		w.Write([]byte(`{"code":0,"message":"","data":{}}`))
+	}))
+	defer ts.Close()
+	s := New("", mockLogger{}, SessionWithAPIBase(ts.URL))
+	s.Client.Timeout = 5 * time.Second
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.Request("GET", EndpointUserMe, nil); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,190 @@
+package kook
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ReconnectPolicy decides whether and when to reconnect after a failed connection attempt.
+//
+// A policy is used by one session at a time, from the reconnecting goroutine.
+// A *GatewayError with EventStatusInvalidToken or EventStatusTokenAuthFailed is never retried regardless of the policy,
+// and LifecycleGaveUp is emitted instead. Other refusals, like EventStatusTokenExpired, follow the policy.
+type ReconnectPolicy interface {
+	// Next returns the wait before the next attempt after the attempt-th attempt failed with err.
+	// Returning false stops reconnecting.
+	Next(attempt int, err error) (wait time.Duration, ok bool)
+	// Reset is called when a connection is established.
+	Reset()
+}
+
+// ExponentialBackoff is the default ReconnectPolicy, doubling the wait after every failure with random jitter.
+type ExponentialBackoff struct {
+	// Initial is the wait after the first failure.
+	Initial time.Duration
+	// Max is the maximum wait between attempts.
+	Max time.Duration
+	// Multiplier is the factor of the wait growth.
+	Multiplier float64
+	// Jitter is the fraction of the wait randomized, e.g. 0.2 for ±20%.
+	Jitter float64
+	// MaxAttempts stops reconnecting after so many failures, 0 means retrying forever.
+	MaxAttempts int
+	// BreakerThreshold opens the circuit after so many consecutive failures, 0 disables the breaker.
+	// No attempt is made while the circuit is open. After BreakerCooldown it is half-open, and one attempt is made:
+	// a success closes the circuit, while a failure opens it again.
+	BreakerThreshold int
+	// BreakerCooldown is how long the circuit stays open, DefaultBreakerCooldown if not set.
+	BreakerCooldown time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	open      bool
+	openUntil time.Time
+}
+
+// DefaultBreakerCooldown is the cooldown of ExponentialBackoff if BreakerCooldown is not set.
+const DefaultBreakerCooldown = time.Minute
+
+// BreakerState is the type for states of the circuit breaker of ExponentialBackoff.
+type BreakerState int
+
+// These are the states of the circuit breaker.
+const (
+	// BreakerClosed is the normal state, with waits growing exponentially.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen is the state after too many failures, no attempt is made until the cooldown ends.
+	BreakerOpen
+	// BreakerHalfOpen is the state after the cooldown, one attempt is made to probe the connection.
+	BreakerHalfOpen
+)
+
+// NewExponentialBackoff creates the default reconnect policy, which waits from 1 second up to 10 minutes
+// with 20% jitter, and retries forever.
+func NewExponentialBackoff() *ExponentialBackoff {
+	return &ExponentialBackoff{
+		Initial:    time.Second,
+		Max:        600 * time.Second,
+		Multiplier: 2,
+		Jitter:     0.2,
+	}
+}
+
+// Next implements ReconnectPolicy.
+func (b *ExponentialBackoff) Next(attempt int, err error) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.MaxAttempts > 0 && attempt >= b.MaxAttempts {
+		return 0, false
+	}
+	if b.BreakerThreshold > 0 {
+		now := time.Now()
+		switch b.stateLocked(now) {
+		case BreakerOpen:
+			return b.openUntil.Sub(now), true
+		case BreakerHalfOpen:
+			return b.trip(now), true
+		default:
+			if b.failures >= b.BreakerThreshold {
+				return b.trip(now), true
+			}
+		}
+	}
+	wait := float64(b.Initial)
+	for i := 1; i < attempt; i++ {
+		wait *= b.Multiplier
+		if b.Max > 0 && wait > float64(b.Max) {
+			wait = float64(b.Max)
+			break
+		}
+	}
+	if b.Jitter > 0 {
+		wait += wait * b.Jitter * (2*rand.Float64() - 1)
+	}
+	if b.Max > 0 && wait > float64(b.Max) {
+		wait = float64(b.Max)
+	}
+	return time.Duration(wait), true
+}
+
+// trip opens the circuit, returning the cooldown.
+func (b *ExponentialBackoff) trip(now time.Time) time.Duration {
+	cooldown := b.BreakerCooldown
+	if cooldown <= 0 {
+		cooldown = DefaultBreakerCooldown
+	}
+	b.open = true
+	b.openUntil = now.Add(cooldown)
+	return cooldown
+}
+
+func (b *ExponentialBackoff) stateLocked(now time.Time) BreakerState {
+	switch {
+	case !b.open:
+		return BreakerClosed
+	case now.Before(b.openUntil):
+		return BreakerOpen
+	default:
+		return BreakerHalfOpen
+	}
+}
+
+// State returns the state of the circuit breaker.
+func (b *ExponentialBackoff) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stateLocked(time.Now())
+}
+
+// Reset implements ReconnectPolicy, closing the circuit.
+func (b *ExponentialBackoff) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.open = false
+}
+
+// SessionWithReconnectPolicy replaces the reconnect policy of the session.
+func SessionWithReconnectPolicy(p ReconnectPolicy) SessionOption {
+	return func(session *Session) {
+		session.ReconnectPolicy = p
+	}
+}
+
+// SessionWithContext binds the session to ctx. When ctx is done, the connection is closed and reconnecting stops.
+func SessionWithContext(ctx context.Context) SessionOption {
+	return func(session *Session) {
+		session.ctx = ctx
+	}
+}
+
+// isFatalGatewayError reports whether a connection error would fail again on retry, which is a *GatewayError
+// with EventStatusInvalidToken or EventStatusTokenAuthFailed, or an unauthorized http api response.
+func isFatalGatewayError(err error) bool {
+	var e *GatewayError
+	if errors.As(err, &e) {
+		return e.Fatal()
+	}
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// stopReconnect cancels the running reconnect loop, if any.
+func (s *Session) stopReconnect() {
+	s.Lock()
+	defer s.Unlock()
+	if s.reconnectStop != nil {
+		close(s.reconnectStop)
+		s.reconnectStop = nil
+	}
+}
+
+func (s *Session) context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
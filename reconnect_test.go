@@ -0,0 +1,121 @@
+package kook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestExponentialBackoff_Next(t *testing.T) {
+	b := &ExponentialBackoff{Initial: time.Second, Max: 10 * time.Second, Multiplier: 2, MaxAttempts: 6}
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second} {
+		if get, ok := b.Next(i+1, nil); !ok || get != want {
+			t.Error(i, get, ok)
+		}
+	}
+	if get, ok := b.Next(6, nil); ok {
+		t.Error(get)
+	}
+	b = &ExponentialBackoff{Initial: time.Second, Multiplier: 2, BreakerThreshold: 2, BreakerCooldown: time.Minute}
+	if get, _ := b.Next(1, nil); get != time.Second || b.State() != BreakerClosed {
+		t.Error(get)
+	}
+	if get, _ := b.Next(2, nil); get != time.Minute || b.State() != BreakerOpen {
+		t.Error(get)
+	}
+	if get, _ := b.Next(3, nil); get <= 0 || get > time.Minute {
+		t.Error(get)
+	}
+	b.openUntil = time.Now()
+	if get := b.State(); get != BreakerHalfOpen {
+		t.Error(get)
+	}
+	if get, _ := b.Next(4, nil); get != time.Minute || b.State() != BreakerOpen {
+		t.Error(get)
+	}
+	b.Reset()
+	if get, _ := b.Next(1, nil); get != time.Second || b.State() != BreakerClosed {
+		t.Error(get)
+	}
+	b = &ExponentialBackoff{Initial: time.Second, BreakerThreshold: 1}
+	if get, _ := b.Next(1, nil); get != DefaultBreakerCooldown {
+		t.Error(get)
+	}
+}
+
+func TestIsFatalGatewayError(t *testing.T) {
+	if !isFatalGatewayError(&GatewayError{Code: EventStatusTokenAuthFailed}) {
+		t.Error("auth failure should be fatal")
+	}
+	if isFatalGatewayError(&GatewayError{Code: EventStatusResumeFailed}) {
+		t.Error("resume failure should not be fatal")
+	}
+	if isFatalGatewayError(errors.New("eof")) {
+		t.Error("other errors should not be fatal")
+	}
+}
+
+func TestSession_ReconnectAfterTokenExpired(t *testing.T) {
+	var mu sync.Mutex
+	indexes, dials := 0, 0
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/v3/gateway/index" {
+			indexes++
+			w.Write([]byte(`{"code":0,"message":"","data":{"url":"ws` + strings.TrimPrefix(ts.URL, "http") + `/gateway"}}`))
+			return
+		}
+		c, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		dials++
+		code := 0
+		if dials == 1 {
+			code = int(EventStatusTokenExpired)
+		}
+		c.WriteMessage(websocket.TextMessage, []byte(`{"s":1,"d":{"code":`+strconv.Itoa(code)+`,"session_id":"s1"}}`))
+		go func() {
+			defer c.Close()
+			for {
+				if _, _, err := c.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+	}))
+	defer ts.Close()
+	s := New("", mockLogger{}, SessionWithAPIBase(ts.URL),
+		SessionWithReconnectPolicy(&ExponentialBackoff{Initial: time.Millisecond, Multiplier: 1}))
+	events := make(chan LifecycleEvent, 16)
+	s.AddLifecycleHandler(func(c *LifecycleContext) {
+		events <- c.Event
+	})
+	err := s.Open()
+	if !errors.Is(err, EventStatusTokenExpired) || isFatalGatewayError(err) {
+		t.Fatal(err)
+	}
+	s.reconnect()
+	defer s.Close()
+	var got []LifecycleEvent
+	for len(events) > 0 {
+		got = append(got, <-events)
+	}
+	if len(got) == 0 || got[len(got)-1] != LifecycleConnected {
+		t.Error(got)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if indexes != 2 || dials != 2 {
+		t.Error(indexes, dials)
+	}
+}
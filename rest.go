@@ -2,7 +2,9 @@ package kook
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
@@ -234,7 +236,7 @@ func (s *Session) MessageAddReaction(msgID, emoji string) (err error) {
 	_, err = s.Request("POST", EndpointMessageAddReaction, struct {
 		MsgID string `json:"msg_id"`
 		Emoji string `json:"emoji"`
-	}{msgID, emoji})
+	}{msgID, reactionEmoji(emoji)})
 	return err
 }
 
@@ -245,7 +247,7 @@ func (s *Session) MessageDeleteReaction(msgID, emoji string, userID string) (err
 		MsgID  string `json:"msg_id"`
 		Emoji  string `json:"emoji"`
 		UserID string `json:"user_id,omitempty"`
-	}{msgID, emoji, userID})
+	}{msgID, reactionEmoji(emoji), userID})
 	return err
 }
 
@@ -635,6 +637,13 @@ func DirectMessageListWithFlag(flag MessageListFlag) DirectMessageListOption {
 	}
 }
 
+// DirectMessageListWithPageSize adds optional `page_size` argument to DirectMessageList request.
+func DirectMessageListWithPageSize(size int) DirectMessageListOption {
+	return func(values url.Values) {
+		values.Set("page_size", strconv.Itoa(size))
+	}
+}
+
 // DirectMessageResp is the type for direct messages.
 type DirectMessageResp struct {
 	ID          string              `json:"id"`
@@ -669,11 +678,21 @@ func (s *Session) DirectMessageList(options ...DirectMessageListOption) (dmrs []
 	if err != nil {
 		return nil, err
 	}
-	err = json.Unmarshal(response, &dmrs)
+	if bytes.HasPrefix(bytes.TrimSpace(response), []byte("[")) {
+		err = json.Unmarshal(response, &dmrs)
+		if err != nil {
+			return nil, err
+		}
+		return dmrs, nil
+	}
+	data := struct {
+		Items []*DirectMessageResp `json:"items"`
+	}{}
+	err = json.Unmarshal(response, &data)
 	if err != nil {
 		return nil, err
 	}
-	return dmrs, nil
+	return data.Items, nil
 }
 
 // DirectMessageView returns the specified message.
@@ -761,7 +780,7 @@ func (s *Session) DirectMessageAddReaction(msgID, emoji string) (err error) {
 	_, err = s.Request("POST", EndpointDirectMessageAddReaction, struct {
 		MsgID string `json:"msg_id"`
 		Emoji string `json:"emoji"`
-	}{msgID, emoji})
+	}{msgID, reactionEmoji(emoji)})
 	return err
 }
 
@@ -809,7 +828,7 @@ func (s *Session) DirectMessageDeleteReaction(msgID, emoji string) (err error) {
 	_, err = s.Request("POST", EndpointDirectMessageDeleteReaction, struct {
 		MsgID string `json:"msg_id"`
 		Emoji string `json:"emoji"`
-	}{msgID, emoji})
+	}{msgID, reactionEmoji(emoji)})
 	return err
 }
 
@@ -914,7 +933,6 @@ type GuildUserListInfo struct {
 // GuildUserList returns the list of users in a guild.
 // FYI: https://developer.kookapp.cn/doc/http/guild#%E8%8E%B7%E5%8F%96%E6%9C%8D%E5%8A%A1%E5%99%A8%E4%B8%AD%E7%9A%84%E7%94%A8%E6%88%B7%E5%88%97%E8%A1%A8
 func (s *Session) GuildUserList(guildID string, page *PageSetting, options ...GuildUserListOption) (us []*User, guli *GuildUserListInfo, meta *PageInfo, err error) {
-	var response []byte
 	u, _ := url.Parse(EndpointGuildUserList)
 	q := u.Query()
 	q.Set("guild_id", guildID)
@@ -945,7 +963,7 @@ func (s *Session) GuildUserList(guildID string, page *PageSetting, options ...Gu
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	err = json.Unmarshal(response, &us)
+	err = json.Unmarshal(g.Items, &us)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -1606,7 +1624,7 @@ func (s *Session) UserOffline() error {
 
 // BadgeGuildUrl builds the url for guild badge.
 func (s *Session) BadgeGuildUrl(guildID string, style int) string {
-	u, _ := url.Parse(EndpointBadgeGuild)
+	u, _ := url.Parse(s.endpoint(EndpointBadgeGuild))
 	q := u.Query()
 	q.Add("guild_id", guildID)
 	q.Add("style", strconv.Itoa(style))
@@ -1781,7 +1799,11 @@ func (s *Session) RequestWithPage(method, u string, page *PageSetting) (response
 		}
 		ur.RawQuery = q.Encode()
 	}
-	resp, err := s.Request(method, ur.String(), nil)
+	ctx := context.Background()
+	if page != nil && page.ctx != nil {
+		ctx = page.ctx
+	}
+	resp, err := s.request(ctx, method, ur.String(), nil, 0)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1794,26 +1816,38 @@ func (s *Session) RequestWithPage(method, u string, page *PageSetting) (response
 }
 
 // Request is the wrapper for internal request method, you would prefer to use other method other than this.
+//
+// The error is a *RestError if kook answers with a failure, or a *RequestError otherwise.
 func (s *Session) Request(method, url string, data interface{}) (response []byte, err error) {
-	return s.request(method, url, data, 0)
+	return s.request(context.Background(), method, url, data, 0)
 }
 
+// ErrReadLimit is the error when a http api response is larger than Session.ReadLimit.
+var ErrReadLimit = errors.New("read limit exceeded")
+
 type assetFile struct {
 	Payload     []byte
 	ContentType string
 }
 
-func (s *Session) request(method, url string, data interface{}, sequence int) (response []byte, err error) {
+func (s *Session) request(ctx context.Context, method, url string, data interface{}, sequence int) (response []byte, err error) {
+	s.inflight.add(false)
+	defer s.inflight.done(false)
+	url = s.endpoint(url)
 	var body []byte
 	var dataMultipart bool
+	var stream *assetStream
 	if data != nil {
 		if d, ok := data.(*assetFile); ok {
 			body = d.Payload
 			dataMultipart = true
+		} else if d, ok := data.(*assetStream); ok {
+			stream = d
+			ctx = d.ctx
 		} else {
 			body, err = json.Marshal(data)
 			if err != nil {
-				return
+				return nil, &RequestError{Method: method, URL: url, Err: err}
 			}
 		}
 	}
@@ -1824,9 +1858,19 @@ func (s *Session) request(method, url string, data interface{}, sequence int) (r
 		e = e.Bytes("payload", body)
 	}
 	e.Msg("http api request")
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(body))
+	var reqBody io.Reader = bytes.NewBuffer(body)
+	if stream != nil {
+		reqBody = stream.Body
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
 	if err != nil {
-		return
+		return nil, &RequestError{Method: method, URL: url, Err: err}
+	}
+	if stream != nil {
+		req.Header.Set("Content-Type", stream.ContentType)
+	}
+	for k, v := range s.Header {
+		req.Header[k] = append(req.Header[k], v...)
 	}
 	req.Header.Set("Authorization", s.Identify.Token)
 	if len(body) > 0 {
@@ -1842,10 +1886,23 @@ func (s *Session) request(method, url string, data interface{}, sequence int) (r
 		// s.log(LogTrace, "Api Request Header %s = %+v\n", k, v)
 	}
 	e.Msg("http api request headers")
+	var bucket *Bucket
+	if s.RateLimiter != nil {
+		bucket, err = s.RateLimiter.LockBucketContext(ctx, s.rateLimitKey(url))
+		if err != nil {
+			return nil, &RequestError{Method: method, URL: url, Err: err}
+		}
+	}
 	resp, err := s.Client.Do(req)
 	if err != nil {
+		if bucket != nil {
+			bucket.Release(nil)
+		}
 		addCaller(s.Logger.Error()).Err("err", err).Msg("")
-		return
+		return nil, &RequestError{Method: method, URL: url, Err: err}
+	}
+	if bucket != nil {
+		bucket.Release(resp.Header)
 	}
 	defer func() {
 		err2 := resp.Body.Close()
@@ -1857,10 +1914,18 @@ func (s *Session) request(method, url string, data interface{}, sequence int) (r
 
 	var respByte []byte
 
-	respByte, err = ioutil.ReadAll(resp.Body)
+	var respBody io.Reader = resp.Body
+	if s.ReadLimit > 0 {
+		respBody = io.LimitReader(resp.Body, s.ReadLimit+1)
+	}
+	respByte, err = ioutil.ReadAll(respBody)
 	if err != nil {
 		addCaller(s.Logger.Error()).Err("err", err).Msg("")
-		return
+		return nil, &RequestError{Method: method, URL: url, Err: err}
+	}
+	if s.ReadLimit > 0 && int64(len(respByte)) > s.ReadLimit {
+		addCaller(s.Logger.Error()).Int64("read_limit", s.ReadLimit).Msg("response exceeds read limit")
+		return nil, &RequestError{Method: method, URL: url, Err: ErrReadLimit}
 	}
 	addCaller(s.Logger.Trace()).Int("status_code", resp.StatusCode).
 		Str("status", resp.Status).
@@ -1874,18 +1939,28 @@ func (s *Session) request(method, url string, data interface{}, sequence int) (r
 		// s.log(LogTrace, "Api Response Header %s = %+v\n", k, v)
 	}
 	e.Msg("http response headers")
+	if resp.StatusCode == http.StatusTooManyRequests && sequence < s.MaxRetry && stream == nil {
+		addCaller(s.Logger.Warn()).Str("url", url).Int("retry", sequence+1).Msg("rate limited, retrying")
+		return s.request(ctx, method, url, data, sequence+1)
+	}
 	// s.log(LogTrace, "Api Response Body %s", respByte)
 	var r EndpointGeneralResponse
 	err = json.Unmarshal(respByte, &r)
 	if err != nil {
 		addCaller(s.Logger.Error()).Err("err", err).Msg("response unmarshal error")
 		// s.log(LogError, "Api Response Unmarshal Error %s", err)
-		return
+		if resp.StatusCode >= 300 {
+			return nil, newRestErrorFromGeneralResp(&EndpointGeneralResponse{Message: resp.Status}, resp)
+		}
+		return nil, &RequestError{Method: method, URL: url, Err: err}
+	}
+	if r.Code == 0 && resp.StatusCode >= 300 {
+		r.Message = resp.Status
 	}
-	if r.Code != 0 {
-		addCaller(s.Logger.Error()).Int("code", r.Code).Str("error_msg", r.Message).Msg("api response error")
+	if r.Code != 0 || resp.StatusCode >= 300 {
+		addCaller(s.Logger.Error()).Int("code", r.Code).Int("status_code", resp.StatusCode).Str("error_msg", r.Message).Msg("api response error")
 		// s.log(LogError, "Api Response Error Code %d, Message %s", r.Code, r.Message)
-		return nil, newRestErrorFromGeneralResp(&r)
+		return nil, newRestErrorFromGeneralResp(&r, resp)
 	}
 	response = r.Data
 	return
@@ -0,0 +1,229 @@
+package kook
+
+import "errors"
+
+// ErrTempTargetDirect is the error when sending a temporary message to a direct chat.
+var ErrTempTargetDirect = errors.New("temporary messages are only supported in channels")
+
+// ErrUnknownTarget is the error when sending a message to a nil Target.
+var ErrUnknownTarget = errors.New("unknown message target")
+
+// Target is the destination of messages, which is one of ChannelTarget, UserTarget and ChatCodeTarget.
+type Target interface {
+	// Direct reports whether the target is a direct chat.
+	Direct() bool
+	target()
+}
+
+// ChannelTarget is a channel to send messages to, by channel id.
+type ChannelTarget string
+
+// Direct implements Target.
+func (t ChannelTarget) Direct() bool {
+	return false
+}
+
+func (t ChannelTarget) target() {}
+
+// UserTarget is a user to send direct messages to, by user id.
+type UserTarget string
+
+// Direct implements Target.
+func (t UserTarget) Direct() bool {
+	return true
+}
+
+func (t UserTarget) target() {}
+
+// ChatCodeTarget is a direct chat to send messages to, by chat code.
+type ChatCodeTarget string
+
+// Direct implements Target.
+func (t ChatCodeTarget) Direct() bool {
+	return true
+}
+
+func (t ChatCodeTarget) target() {}
+
+// SendOption is the type for optional arguments of Send.
+type SendOption func(*sendConfig)
+
+type sendConfig struct {
+	typ          MessageType
+	quote        string
+	nonce        string
+	tempTargetID string
+}
+
+// SendWithType sets the message type, MessageTypeText by default.
+func SendWithType(t MessageType) SendOption {
+	return func(c *sendConfig) {
+		c.typ = t
+	}
+}
+
+// SendWithKMarkdown sends the content as KMarkdown.
+func SendWithKMarkdown() SendOption {
+	return SendWithType(MessageTypeKMarkdown)
+}
+
+// SendWithCard sends the content as card message json.
+func SendWithCard() SendOption {
+	return SendWithType(MessageTypeCard)
+}
+
+// SendWithQuote quotes the message.
+func SendWithQuote(msgID string) SendOption {
+	return func(c *sendConfig) {
+		c.quote = msgID
+	}
+}
+
+// SendWithNonce sets the nonce echoed in the message event.
+func SendWithNonce(nonce string) SendOption {
+	return func(c *sendConfig) {
+		c.nonce = nonce
+	}
+}
+
+// SendWithTempTarget sends a temporary message only visible to the user, which is only supported in channels.
+func SendWithTempTarget(userID string) SendOption {
+	return func(c *sendConfig) {
+		c.tempTargetID = userID
+	}
+}
+
+// Message is the handle of a sent message, which could be edited, deleted and reacted to.
+type Message struct {
+	Session      *Session
+	Target       Target
+	ID           string
+	Timestamp    MilliTimeStamp
+	Nonce        string
+	TempTargetID string
+}
+
+// Send sends a message to the target, which could be a channel or a direct chat.
+func (s *Session) Send(target Target, content string, options ...SendOption) (*Message, error) {
+	c := &sendConfig{}
+	for _, item := range options {
+		item(c)
+	}
+	base := MessageCreateBase{
+		Type:    c.typ,
+		Content: content,
+		Quote:   c.quote,
+		Nonce:   c.nonce,
+	}
+	var resp *MessageResp
+	var err error
+	switch t := target.(type) {
+	case ChannelTarget:
+		base.TargetID = string(t)
+		resp, err = s.MessageCreate(&MessageCreate{MessageCreateBase: base, TempTargetID: c.tempTargetID})
+	case UserTarget:
+		if c.tempTargetID != "" {
+			return nil, ErrTempTargetDirect
+		}
+		base.TargetID = string(t)
+		resp, err = s.DirectMessageCreate(&DirectMessageCreate{MessageCreateBase: base})
+	case ChatCodeTarget:
+		if c.tempTargetID != "" {
+			return nil, ErrTempTargetDirect
+		}
+		resp, err = s.DirectMessageCreate(&DirectMessageCreate{MessageCreateBase: base, ChatCode: string(t)})
+	default:
+		return nil, ErrUnknownTarget
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Message{
+		Session:      s,
+		Target:       target,
+		ID:           resp.MsgID,
+		Timestamp:    resp.MsgTimestamp,
+		Nonce:        resp.Nonce,
+		TempTargetID: c.tempTargetID,
+	}, nil
+}
+
+// SendCard sends a card message to the target.
+func (s *Session) SendCard(target Target, card CardMessage, options ...SendOption) (*Message, error) {
+	content, err := card.BuildMessage()
+	if err != nil {
+		return nil, err
+	}
+	return s.Send(target, content, append(options, SendWithCard())...)
+}
+
+// Edit replaces the content of the message. Only SendWithQuote of the options is used, as kook
+// does not change the type of a message.
+func (m *Message) Edit(content string, options ...SendOption) error {
+	c := &sendConfig{}
+	for _, item := range options {
+		item(c)
+	}
+	base := MessageUpdateBase{
+		MsgID:   m.ID,
+		Content: content,
+		Quote:   c.quote,
+	}
+	if m.Target.Direct() {
+		u := DirectMessageUpdate(base)
+		return m.Session.DirectMessageUpdate(&u)
+	}
+	return m.Session.MessageUpdate(&MessageUpdate{MessageUpdateBase: base, TempTargetID: m.TempTargetID})
+}
+
+// EditCard replaces the content of the card message.
+func (m *Message) EditCard(card CardMessage, options ...SendOption) error {
+	content, err := card.BuildMessage()
+	if err != nil {
+		return err
+	}
+	return m.Edit(content, options...)
+}
+
+// Delete deletes the message.
+func (m *Message) Delete() error {
+	if m.Target.Direct() {
+		return m.Session.DirectMessageDelete(m.ID)
+	}
+	return m.Session.MessageDelete(m.ID)
+}
+
+// React adds the emoji reaction to the message.
+func (m *Message) React(emoji string) error {
+	if m.Target.Direct() {
+		return m.Session.DirectMessageAddReaction(m.ID, emoji)
+	}
+	return m.Session.MessageAddReaction(m.ID, emoji)
+}
+
+// Unreact deletes the emoji reaction of the bot from the message.
+func (m *Message) Unreact(emoji string) error {
+	if m.Target.Direct() {
+		return m.Session.DirectMessageDeleteReaction(m.ID, emoji)
+	}
+	return m.Session.MessageDeleteReaction(m.ID, emoji, "")
+}
+
+// eventMessage builds the handle of the message of an event. Messages in direct chats are replied to the user.
+func (c *EventHandlerCommonContext) eventMessage(msgID, targetID, userID string, direct bool) *Message {
+	var target Target = ChannelTarget(targetID)
+	if direct {
+		target = UserTarget(userID)
+	}
+	return &Message{Session: c.Session, Target: target, ID: msgID}
+}
+
+// reply sends a message quoting m to where m is.
+func (m *Message) reply(content string, options []SendOption) (*Message, error) {
+	return m.Session.Send(m.Target, content, append([]SendOption{SendWithQuote(m.ID)}, options...)...)
+}
+
+// replyCard sends a card message quoting m to where m is.
+func (m *Message) replyCard(card CardMessage, options []SendOption) (*Message, error) {
+	return m.Session.SendCard(m.Target, card, append([]SendOption{SendWithQuote(m.ID)}, options...)...)
+}
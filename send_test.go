@@ -0,0 +1,78 @@
+package kook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSession_Send(t *testing.T) {
+	var path string
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"code":0,"message":"","data":{"msg_id":"m1","msg_timestamp":1,"nonce":"n"}}`))
+	}))
+	defer ts.Close()
+	s := New("", mockLogger{}, SessionWithAPIBase(ts.URL))
+	m, err := s.Send(ChannelTarget("c1"), "hi", SendWithKMarkdown(), SendWithTempTarget("u1"), SendWithQuote("q"))
+	if err != nil || m.ID != "m1" || path != "/v3/message/create" {
+		t.Fatal(m, err, path)
+	}
+	if body["target_id"] != "c1" || body["type"] != float64(MessageTypeKMarkdown) || body["temp_target_id"] != "u1" || body["quote"] != "q" {
+		t.Error(body)
+	}
+	if err = m.Edit("edited"); err != nil || path != "/v3/message/update" || body["temp_target_id"] != "u1" {
+		t.Error(body, err)
+	}
+	m, err = s.Send(ChatCodeTarget("code"), "hi")
+	if err != nil || path != "/v3/direct-message/create" || body["chat_code"] != "code" {
+		t.Error(body, err)
+	}
+	if err = m.React("👍"); err != nil || path != "/v3/direct-message/add-reaction" {
+		t.Error(path, err)
+	}
+	if _, err = s.Send(UserTarget("u1"), "hi", SendWithTempTarget("u1")); err != ErrTempTargetDirect {
+		t.Error(err)
+	}
+}
+
+func TestEventContext_Reply(t *testing.T) {
+	var path string
+	var body map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Write([]byte(`{"code":0,"message":"","data":{"msg_id":"m2"}}`))
+	}))
+	defer ts.Close()
+	s := New("", mockLogger{}, SessionWithAPIBase(ts.URL))
+	ctx := &KmarkdownMessageContext{EventHandlerCommonContext: &EventHandlerCommonContext{
+		Session: s,
+		Common:  &EventDataGeneral{ChannelType: "PERSON", TargetID: "bot", AuthorID: "u1", MsgID: "m1"},
+	}}
+	if _, err := ctx.Reply("pong"); err != nil || path != "/v3/direct-message/create" || body["target_id"] != "u1" || body["quote"] != "m1" {
+		t.Error(path, body, err)
+	}
+	click := &MessageButtonClickContext{EventHandlerCommonContext: &EventHandlerCommonContext{Session: s}}
+	click.Extra.MsgID, click.Extra.TargetID, click.Extra.UserID, click.Extra.GuildID = "m1", "c1", "u1", "g1"
+	if _, err := click.ReplyTemp("hi"); err != nil || path != "/v3/message/create" || body["target_id"] != "c1" || body["temp_target_id"] != "u1" {
+		t.Error(path, body, err)
+	}
+	text := &TextMessageContext{EventHandlerCommonContext: &EventHandlerCommonContext{
+		Session: s,
+		Common:  &EventDataGeneral{ChannelType: "GROUP", TargetID: "c1", AuthorID: "u1", MsgID: "m1"},
+	}}
+	resp, err := text.Reply("pong", MessageCreateWithKmarkdown(), ReplyOptionTemp, DirectMessageCreateWithCard())
+	if err != nil || resp.MsgID != "m2" || path != "/v3/message/create" || body["type"] != float64(MessageTypeKMarkdown) ||
+		body["temp_target_id"] != "u1" || body["quote"] != "m1" {
+		t.Error(path, body, err)
+	}
+	if _, err = text.Reply("pong", SendWithNonce("n")); err != nil || body["nonce"] != "n" || body["temp_target_id"] != nil {
+		t.Error(path, body, err)
+	}
+}
@@ -0,0 +1,160 @@
+package kook
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrSessionShutdown is the error when using a session which is already shut down.
+var ErrSessionShutdown = errors.New("session is shut down")
+
+// ShutdownError is the error when the context of Shutdown expires before all the work is done.
+type ShutdownError struct {
+	// Handlers is the number of event handlers still running.
+	Handlers int
+	// Requests is the number of http api requests still pending.
+	Requests int
+	Err      error
+}
+
+// Error provides the formatted error string
+func (e *ShutdownError) Error() string {
+	return "shutdown abandoned " + strconv.Itoa(e.Handlers) + " handlers and " +
+		strconv.Itoa(e.Requests) + " requests: " + e.Err.Error()
+}
+
+// Unwrap returns the error of the context.
+func (e *ShutdownError) Unwrap() error {
+	return e.Err
+}
+
+// inflight counts running event handlers and pending http api requests.
+type inflight struct {
+	mu       sync.Mutex
+	handlers int
+	requests int
+	closed   bool
+	waiters  []chan struct{}
+}
+
+// add counts a handler or a request, and reports whether it could be started. Handlers are refused once
+// the session is shutting down, while requests are still allowed for the handlers running.
+func (f *inflight) add(handler bool) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if handler {
+		if f.closed {
+			return false
+		}
+		f.handlers++
+	} else {
+		f.requests++
+	}
+	return true
+}
+
+// close refuses new handlers, it is called when shutdown starts.
+func (f *inflight) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+}
+
+func (f *inflight) done(handler bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if handler {
+		f.handlers--
+	} else {
+		f.requests--
+	}
+	if f.handlers == 0 && f.requests == 0 {
+		for _, item := range f.waiters {
+			close(item)
+		}
+		f.waiters = nil
+	}
+}
+
+func (f *inflight) wait(ctx context.Context) error {
+	f.mu.Lock()
+	if f.handlers == 0 && f.requests == 0 {
+		f.mu.Unlock()
+		return nil
+	}
+	c := make(chan struct{})
+	f.waiters = append(f.waiters, c)
+	f.mu.Unlock()
+	select {
+	case <-c:
+		return nil
+	case <-ctx.Done():
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for i, item := range f.waiters {
+			if item == c {
+				f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+				break
+			}
+		}
+		return &ShutdownError{Handlers: f.handlers, Requests: f.requests, Err: ctx.Err()}
+	}
+}
+
+func (s *Session) isShutdown() bool {
+	return atomic.LoadInt32(&s.shutdown) == 1
+}
+
+// Shutdown gracefully stops the session. A session could not be opened again after shutdown.
+//
+// It stops dispatching new events, sends the close frame, and waits until running event handlers and
+// pending http api requests finish. If ctx expires first, a *ShutdownError reporting the abandoned work is returned.
+func (s *Session) Shutdown(ctx context.Context) (err error) {
+	addCaller(s.Logger.Info()).Msg("called")
+	atomic.StoreInt32(&s.shutdown, 1)
+	s.inflight.close()
+	s.stopReconnect()
+
+	s.Lock()
+	if s.listening != nil {
+		addCaller(s.Logger.Info()).Msg("closing listening channel")
+		close(s.listening)
+		s.listening = nil
+	}
+	if s.wsConn != nil {
+		addCaller(s.Logger.Info()).Msg("sending close frame")
+		s.wsMutex.Lock()
+		err := s.wsConn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+		s.wsMutex.Unlock()
+		if err != nil {
+			addCaller(s.Logger.Info()).Err("err", err).Msg("error closing websocket")
+		}
+	}
+	s.Unlock()
+
+	err = s.inflight.wait(ctx)
+	if err != nil {
+		addCaller(s.Logger.Warn()).Err("err", err).Msg("shutdown before all work is done")
+	}
+
+	s.Lock()
+	disconnected := s.wsConn != nil
+	if disconnected {
+		addCaller(s.Logger.Info()).Msg("closing gateway websocket")
+		if err := s.wsConn.Close(); err != nil {
+			addCaller(s.Logger.Info()).Err("err", err).Msg("error closing websocket")
+		}
+		s.wsConn = nil
+	}
+	s.state = SessionStateShutdown
+	s.Unlock()
+	if disconnected {
+		s.emitLifecycle(LifecycleDisconnected, 0, ErrSessionShutdown)
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package kook
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestInflight(t *testing.T) {
+	var f inflight
+	if !f.add(true) || !f.add(false) {
+		t.Fatal("add refused")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var se *ShutdownError
+	if err := f.wait(ctx); !errors.As(err, &se) || se.Handlers != 1 || se.Requests != 1 {
+		t.Error(err)
+	}
+	if len(f.waiters) != 0 {
+		t.Error(len(f.waiters))
+	}
+	f.close()
+	if f.add(true) {
+		t.Error("handler added after close")
+	}
+	if !f.add(false) {
+		t.Error("request refused after close")
+	}
+}
+
+func TestSession_ShutdownDropsHandlers(t *testing.T) {
+	s := New("", mockLogger{})
+	called := false
+	s.Sync = true
+	s.AddHandler(func(ctx *TextMessageContext) {
+		called = true
+	})
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	s.handle(TextMessageEventHandler(nil).Type(), &TextMessageContext{})
+	if called {
+		t.Error("handler called after shutdown")
+	}
+}
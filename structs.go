@@ -1,15 +1,15 @@
 package kook
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
-	"strconv"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/lonelyevil/kook/emoji"
 )
 
 // RolePermission is the type for the permission of a user in guilds or channels.
@@ -238,15 +238,17 @@ const (
 type EventStatusCode int
 
 // EventStatusCode consts for event status
+//
+// FYI: https://developer.kookapp.cn/doc/websocket
 const (
-	EventStatusOk              EventStatusCode = 0
-	EventStatusMissingArgument EventStatusCode = 40100 + iota
-	EventStatusInvalidToken
-	EventStatusTokenAuthFailed
-	EventStatusTokenExpired
-	EventStatusResumeFailed EventStatusCode = 40100 + 2 + iota
-	EventStatusSessionExpired
-	EventStatusInvalidSequenceNumber
+	EventStatusOk                    EventStatusCode = 0
+	EventStatusMissingArgument       EventStatusCode = 40100
+	EventStatusInvalidToken          EventStatusCode = 40101
+	EventStatusTokenAuthFailed       EventStatusCode = 40102
+	EventStatusTokenExpired          EventStatusCode = 40103
+	EventStatusResumeFailed          EventStatusCode = 40106
+	EventStatusSessionExpired        EventStatusCode = 40107
+	EventStatusInvalidSequenceNumber EventStatusCode = 40108
 )
 
 // EventDataHello is the struct for the data of event hello
@@ -278,23 +280,52 @@ type Session struct {
 	LastHeartbeatAck  time.Time
 	LastHeartbeatSent time.Time
 	Client            *http.Client
+	Dialer            *websocket.Dialer
+	Header            http.Header
+	ReadLimit         int64
+	RateLimiter       *RateLimiter
+	ReconnectPolicy   ReconnectPolicy
 	MaxRetry          int
 	RetryTimeout      time.Duration
 	ContentType       string
 	Logger            Logger
 	Sync              bool
 
-	wsConn  *websocket.Conn
-	wsMutex sync.Mutex
-	gateway string
-	// sessionID string
+	wsConn    *websocket.Conn
+	wsMutex   sync.Mutex
+	gateway   string
+	sessionID string
 	sequence  *int64
 	listening chan interface{}
 
-	handlersMu sync.RWMutex
-	handlers   map[string][]*eventHandlerInstance
+	handlersMu        sync.RWMutex
+	handlers          map[string][]*eventHandlerInstance
+	lifecycleHandlers []*lifecycleHandlerInstance
+
+	state            SessionState
+	reconnectAttempt int
+	reconnecting     int32
+	reconnectStop    chan struct{}
+	closed           bool
+	ctx              context.Context
+
+	pong              chan struct{}
+	latency           latencyWindow
+	heartbeatInterval time.Duration
+	heartbeatTimeout  time.Duration
+	heartbeatJitter   time.Duration
 
 	snStore SnStore
+
+	endpointAPI  string
+	clientShared bool
+	dialerShared bool
+
+	inflight inflight
+	shutdown int32
+
+	botIDMu sync.Mutex
+	botID   string
 }
 
 // EventDataGeneral is the struct passed to all event handler.
@@ -337,8 +368,6 @@ type EndpointGeneralResponse struct {
 	Data    json.RawMessage `json:"data"`
 }
 
-const heartbeatInterval = time.Second * 30
-
 // MessageReaction is the struct for reactions embedded to a message.
 type MessageReaction struct {
 	MsgID     string    `json:"msg_id"`
@@ -354,23 +383,27 @@ type EmojiItem struct {
 	Name string `json:"name"`
 }
 
-// IsEqual compares standard emoji string with kook's emoji representation.
+// IsEqual compares emoji in any representation, see package emoji, with kook's emoji representation.
 func (e *EmojiItem) IsEqual(s string) bool {
-	return e.Convert() == s
+	return emoji.Equal(e.ID, s)
 }
 
-// Convert converts kook's emoji to standard emoji.
+// Convert converts kook's emoji to standard emoji, or the id of a guild emoji.
+// The id is returned as is if it could not be parsed.
 func (e *EmojiItem) Convert() string {
-	if !strings.HasPrefix(e.ID, "[#") {
-		return e.ID
-	}
-	t := strings.TrimLeft(e.ID, "[#")
-	t = strings.TrimRight(t, ";]")
-	i, err := strconv.Atoi(t)
+	return emoji.Normalize(e.ID)
+}
+
+// Emoji parses kook's emoji, with the name of guild emoji filled.
+func (e *EmojiItem) Emoji() (emoji.Emoji, error) {
+	r, err := emoji.Parse(e.ID)
 	if err != nil {
-		return ""
+		return r, err
 	}
-	return string([]rune{int32(i)})
+	if r.Kind == emoji.KindGuild && r.Name == "" {
+		r.Name = e.Name
+	}
+	return r, nil
 }
 
 // ChannelMessage is the struct for a message in a channel.
@@ -403,7 +436,9 @@ type DetailedChannelMessage struct {
 		MentionPart     []*User `json:"mention_part"`
 		MentionRolePart []*Role `json:"mention_role_part"`
 	} `json:"mention_info"`
-	ChannelID string `json:"channel_id"`
+	ChannelID string         `json:"channel_id"`
+	CreateAt  MilliTimeStamp `json:"create_at"`
+	UpdatedAt MilliTimeStamp `json:"updated_at"`
 }
 
 // ReactionItem is the reactions for a emoji to a message.
@@ -510,6 +545,8 @@ type PageSetting struct {
 	Page     *int    `json:"page"`
 	PageSize *int    `json:"page_size"`
 	Sort     *string `json:"sort"`
+	// ctx is set by the iterators, so that waiting for the rate limit and the request could be canceled.
+	ctx context.Context
 }
 
 // EventHandlerCommonContext is the common context for event handlers.
@@ -6,12 +6,23 @@ import (
 	"gopkg.in/yaml.v2"
 	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
+	"sort"
 	"unicode"
 )
 
+// HelperConfig is the go expressions locating the message of an event, for generating helpers like Reply.
+type HelperConfig struct {
+	MsgID    string `yaml:"msg_id"`
+	TargetID string `yaml:"target_id"`
+	UserID   string `yaml:"user_id"`
+	Direct   string `yaml:"direct"`
+}
+
 type EventConfig struct {
-	TypeAlias map[string]string `yaml:"type_alias"`
+	TypeAlias map[string]string       `yaml:"type_alias"`
+	Helpers   map[string]HelperConfig `yaml:"helpers"`
 	Templates map[string]struct {
 		Body map[string]string `yaml:"body"`
 	} `yaml:"templates"`
@@ -19,6 +30,10 @@ type EventConfig struct {
 		Name     string             `yaml:"name"`
 		Template *string            `yaml:"template"`
 		Body     *map[string]string `yaml:"body"`
+		Helpers  string             `yaml:"helpers"`
+		Mentions bool               `yaml:"mentions"`
+		// LegacyReply skips generating Reply, which is written by hand with its old signature.
+		LegacyReply bool `yaml:"legacy_reply"`
 	} `yaml:"events"`
 }
 
@@ -42,9 +57,11 @@ func main() {
 Don't edit it.`)
 	f.Comment("revive:disable")
 
-	for typeName, item := range events.Templates {
+	for _, typeName := range sortedKeys(events.Templates) {
+		item := events.Templates[typeName]
 		var c []Code
-		for key, value := range item.Body {
+		for _, key := range sortedStrings(item.Body) {
+			value := item.Body[key]
 			if v, ok := events.TypeAlias[value]; ok {
 				value = v
 			}
@@ -59,7 +76,8 @@ Don't edit it.`)
 	}
 	var initCode []Code
 	var caseCode []Code
-	for sysEvent, item := range events.Events {
+	for _, sysEvent := range sortedKeys(events.Events) {
+		item := events.Events[sysEvent]
 		f.Type().Id(item.Name + "EventHandler").Func().Params(Id("*" + item.Name + "Context"))
 		f.Func().Params(Id("eh").Id(item.Name + "EventHandler")).
 			Id("Type").Params().String().Block(Return(Lit(sysEvent)))
@@ -79,7 +97,8 @@ Don't edit it.`)
 			)
 		} else if item.Body != nil {
 			var c []Code
-			for key, value := range *item.Body {
+			for _, key := range sortedStrings(*item.Body) {
+				value := (*item.Body)[key]
 				if v, ok := events.TypeAlias[value]; ok {
 					value = v
 				}
@@ -102,6 +121,16 @@ Don't edit it.`)
 			Block(If(Id("ctx").Dot("EventHandlerCommonContext").Op("==").Nil()).
 				Block(Id("ctx").Dot("EventHandlerCommonContext").Op("=").New(Id("EventHandlerCommonContext"))),
 				Return(Id("ctx").Dot("EventHandlerCommonContext")))
+		if item.Helpers != "" {
+			h, ok := events.Helpers[item.Helpers]
+			if !ok {
+				panic("unknown helpers " + item.Helpers)
+			}
+			genHelpers(f, item.Name+"Context", h, !item.LegacyReply)
+		}
+		if item.Mentions {
+			genMentions(f, item.Name+"Context")
+		}
 		initCode = append(initCode, Id("registerEventHandler").Call(Id(item.Name+"EventHandler").Call(Nil())))
 		caseCode = append(caseCode, Case(Func().Params(Id("*"+item.Name+"Context"))).Block(Return(Id(item.Name+"EventHandler").Call(Id("v")))))
 	}
@@ -125,6 +154,86 @@ Don't edit it.`)
 
 }
 
+func genHelpers(f *File, ctxType string, h HelperConfig, reply bool) {
+	recv := func() *Statement {
+		return f.Func().Params(Id("ctx").Id("*" + ctxType))
+	}
+	f.Comment("Message returns the handle of the message of the event.")
+	recv().Id("Message").Params().Op("*").Id("Message").Block(
+		Return(Id("ctx").Dot("eventMessage").Call(Id(h.MsgID), Id(h.TargetID), Id(h.UserID), Id(h.Direct))),
+	)
+	if reply {
+		f.Comment("Reply sends a message quoting the message of the event, to where the message is.")
+		recv().Id("Reply").Params(Id("content").String(), Id("options").Op("...").Id("SendOption")).Params(Op("*").Id("Message"), Error()).Block(
+			Return(Id("ctx").Dot("Message").Call().Dot("reply").Call(Id("content"), Id("options"))),
+		)
+	}
+	f.Comment("ReplyCard sends a card message quoting the message of the event, to where the message is.")
+	recv().Id("ReplyCard").Params(Id("card").Id("CardMessage"), Id("options").Op("...").Id("SendOption")).Params(Op("*").Id("Message"), Error()).Block(
+		Return(Id("ctx").Dot("Message").Call().Dot("replyCard").Call(Id("card"), Id("options"))),
+	)
+	f.Comment("ReplyTemp sends a temporary message only visible to the user of the event, which is only supported in channels.")
+	recv().Id("ReplyTemp").Params(Id("content").String(), Id("options").Op("...").Id("SendOption")).Params(Op("*").Id("Message"), Error()).Block(
+		Return(Id("ctx").Dot("Message").Call().Dot("reply").Call(Id("content"), Id("append").Call(Id("options"), Id("SendWithTempTarget").Call(Id(h.UserID))))),
+	)
+	f.Comment("React adds the emoji reaction to the message of the event.")
+	recv().Id("React").Params(Id("emoji").String()).Error().Block(
+		Return(Id("ctx").Dot("Message").Call().Dot("React").Call(Id("emoji"))),
+	)
+	f.Comment("Delete deletes the message of the event.")
+	recv().Id("Delete").Params().Error().Block(
+		Return(Id("ctx").Dot("Message").Call().Dot("Delete").Call()),
+	)
+	f.Comment("Edit replaces the content of the message of the event, which must be sent by the bot.")
+	recv().Id("Edit").Params(Id("content").String(), Id("options").Op("...").Id("SendOption")).Error().Block(
+		Return(Id("ctx").Dot("Message").Call().Dot("Edit").Call(Id("content"), Id("options").Op("..."))),
+	)
+}
+
+func genMentions(f *File, ctxType string) {
+	recv := func() *Statement {
+		return f.Func().Params(Id("ctx").Id("*" + ctxType))
+	}
+	f.Comment("MentionsUser reports whether the user is mentioned explicitly, not including MentionAll and MentionHere.")
+	recv().Id("MentionsUser").Params(Id("userID").String()).Bool().Block(
+		Return(Id("ctx").Dot("Extra").Dot("MentionsUser").Call(Id("userID"))),
+	)
+	f.Comment("MentionsBot reports whether the bot is mentioned explicitly.")
+	recv().Id("MentionsBot").Params().Bool().Block(
+		Return(Id("ctx").Dot("Extra").Dot("mentionsBot").Call(Id("ctx").Dot("Session"))),
+	)
+	f.Comment("MentionedUsers returns the users mentioned explicitly.")
+	recv().Id("MentionedUsers").Params().Index().Id("EventMentionPart").Block(
+		Return(Id("ctx").Dot("Extra").Dot("MentionedUsers").Call()),
+	)
+	f.Comment("MentionedRoles returns the roles mentioned.")
+	recv().Id("MentionedRoles").Params().Index().Id("EventMentionRolePart").Block(
+		Return(Id("ctx").Dot("Extra").Dot("MentionedRoles").Call()),
+	)
+	f.Comment("StripMentions returns the content of the message without mentions of users and roles.")
+	recv().Id("StripMentions").Params().String().Block(
+		Return(Id("StripMentions").Call(Id("ctx").Dot("Common").Dot("Content"))),
+	)
+}
+
+func sortedStrings(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	for _, k := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 var matchId = regexp.MustCompile(`^([a-zA-z]*)(ID)$`)
 
 func convertKeyToJsonKey(k string) string {
@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"strings"
 
@@ -20,90 +21,135 @@ var errWebhookVerify = errors.New("web")
 func (s *Session) WebhookHandler() http.HandlerFunc {
 	return func(writer http.ResponseWriter, request *http.Request) {
 		defer request.Body.Close()
-		var err error
 		addCaller(s.Logger.Trace()).Msg("new request")
 		if request.Method != "POST" {
 			writer.WriteHeader(http.StatusNotFound)
 			return
 		}
-		r := request.Body
-		defer r.Close()
-		buf := &bytes.Buffer{}
-		if !strings.Contains(request.RequestURI, "compress=0") {
-			r, err = zlib.NewReader(r)
-			if err != nil {
-				addCaller(s.Logger.Error().Err("error", err)).Msg("error in init zlib")
-				writer.WriteHeader(http.StatusInternalServerError)
-				return
-			}
-			defer r.Close()
+		if s.isShutdown() {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
 		}
-		_, err = buf.ReadFrom(r)
+		raw, err := ioutil.ReadAll(request.Body)
 		if err != nil {
 			addCaller(s.Logger.Error().Err("error", err)).Msg("error in reading body")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
-		if s.Identify.WebsocketKey != nil {
-			e := &struct {
-				Encrypt string `json:"encrypt"`
-			}{}
-			err = json.NewDecoder(buf).Decode(e)
-			if err != nil {
-				addCaller(s.Logger.Error().Err("error", err)).Msg("error in parsing encrypted request")
-				writer.WriteHeader(http.StatusInternalServerError)
-				return
-			}
-			base64Reader := base64.NewDecoder(base64.StdEncoding, strings.NewReader(e.Encrypt))
-			newBuf := &bytes.Buffer{}
-			_, err = newBuf.ReadFrom(base64Reader)
-			if err != nil {
-				addCaller(s.Logger.Error().Err("error", err)).Msg("error in decoding base64")
-				writer.WriteHeader(http.StatusInternalServerError)
-				return
-			}
-			c, err := aes.NewCipher(s.Identify.WebsocketKey)
-			if err != nil {
-				addCaller(s.Logger.Error().Err("error", err)).Msg("error in creating cipher")
-				writer.WriteHeader(http.StatusInternalServerError)
-				return
-			}
-			dec := cipher.NewCBCDecrypter(c, newBuf.Bytes()[:16])
-			payloadReader := base64.NewDecoder(base64.StdEncoding, bytes.NewReader(newBuf.Bytes()[16:]))
-			buf.Reset()
-			buf.ReadFrom(payloadReader)
-			dec.CryptBlocks(buf.Bytes(), buf.Bytes())
-		}
-		e, err := s.onEvent(websocket.TextMessage, buf.Bytes())
-		if err == errWebhookVerify {
-			i := &struct {
-				Type        int    `json:"type"`
-				ChannelType string `json:"channel_type"`
-				Challenge   string `json:"challenge"`
-				VerifyToken string `json:"verify_token"`
-			}{}
-			err = json.Unmarshal(e.Data, i)
-			if err != nil {
-				addCaller(s.Logger.Error().Err("error", err)).Msg("error in unmarshalling data")
-				writer.WriteHeader(http.StatusInternalServerError)
-				return
-			}
-			if s.Identify.VerifyToken != "" && i.VerifyToken != s.Identify.VerifyToken {
-				addCaller(s.Logger.Warn().Err("error", err)).Msg("received wrong data")
-				writer.WriteHeader(http.StatusUnauthorized)
-				return
-			}
-			_, err = writer.Write([]byte(`{"challenge":"` + i.Challenge + `"}`))
-			if err != nil {
-				s.Logger.Error().Err("error", err).Msg("error in writing to response")
-				return
-			}
-			addCaller(s.Logger.Info()).Msg("webhook challenge done")
+		payload, err := s.decodeWebhook(raw, !strings.Contains(request.RequestURI, "compress=0"))
+		if err != nil {
+			writer.WriteHeader(http.StatusInternalServerError)
 			return
-		} else if err != nil {
-			addCaller(s.Logger.Error().Err("error", err)).Msg("error in parsing event")
+		}
+		s.serveWebhook(writer, payload)
+	}
+}
+
+// decodeWebhook decompresses and decrypts the body of a webhook request, and logs the error if any.
+func (s *Session) decodeWebhook(raw []byte, compressed bool) (payload []byte, err error) {
+	payload, msg, err := s.decodeWebhookPayload(raw, compressed)
+	if err != nil {
+		addCaller(s.Logger.Error().Err("error", err)).Msg(msg)
+	}
+	return payload, err
+}
+
+// decodeWebhookPayload decompresses and decrypts the body of a webhook request without logging,
+// msg describes the step failed. It is used to probe sessions of a SessionManager.
+func (s *Session) decodeWebhookPayload(raw []byte, compressed bool) (payload []byte, msg string, err error) {
+	buf := &bytes.Buffer{}
+	if compressed {
+		r, err := zlib.NewReader(bytes.NewReader(raw))
+		if err != nil {
+			return nil, "error in init zlib", err
+		}
+		defer r.Close()
+		_, err = buf.ReadFrom(r)
+		if err != nil {
+			return nil, "error in reading body", err
+		}
+	} else {
+		buf.Write(raw)
+	}
+	if s.Identify.WebsocketKey != nil {
+		e := &struct {
+			Encrypt string `json:"encrypt"`
+		}{}
+		err = json.NewDecoder(buf).Decode(e)
+		if err != nil {
+			return nil, "error in parsing encrypted request", err
+		}
+		base64Reader := base64.NewDecoder(base64.StdEncoding, strings.NewReader(e.Encrypt))
+		newBuf := &bytes.Buffer{}
+		_, err = newBuf.ReadFrom(base64Reader)
+		if err != nil {
+			return nil, "error in decoding base64", err
+		}
+		if newBuf.Len() < 16 {
+			return nil, "error in decrypting payload", errors.New("encrypted payload too short")
+		}
+		c, err := aes.NewCipher(s.Identify.WebsocketKey)
+		if err != nil {
+			return nil, "error in creating cipher", err
+		}
+		dec := cipher.NewCBCDecrypter(c, newBuf.Bytes()[:16])
+		payloadReader := base64.NewDecoder(base64.StdEncoding, bytes.NewReader(newBuf.Bytes()[16:]))
+		buf.Reset()
+		buf.ReadFrom(payloadReader)
+		if buf.Len()%aes.BlockSize != 0 {
+			return nil, "error in decrypting payload", errors.New("encrypted payload is not a multiple of the block size")
+		}
+		dec.CryptBlocks(buf.Bytes(), buf.Bytes())
+	}
+	return buf.Bytes(), "", nil
+}
+
+// serveWebhook dispatches a decoded webhook payload and answers the challenge.
+func (s *Session) serveWebhook(writer http.ResponseWriter, payload []byte) {
+	e, err := s.onEvent(websocket.TextMessage, payload)
+	if err == errWebhookVerify {
+		i := &struct {
+			Type        int    `json:"type"`
+			ChannelType string `json:"channel_type"`
+			Challenge   string `json:"challenge"`
+			VerifyToken string `json:"verify_token"`
+		}{}
+		err = json.Unmarshal(e.Data, i)
+		if err != nil {
+			addCaller(s.Logger.Error().Err("error", err)).Msg("error in unmarshalling data")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		if s.Identify.VerifyToken != "" && i.VerifyToken != s.Identify.VerifyToken {
+			addCaller(s.Logger.Warn().Err("error", err)).Msg("received wrong data")
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, err = writer.Write([]byte(`{"challenge":"` + i.Challenge + `"}`))
+		if err != nil {
+			s.Logger.Error().Err("error", err).Msg("error in writing to response")
+			return
+		}
+		addCaller(s.Logger.Info()).Msg("webhook challenge done")
+		return
+	} else if err != nil {
+		addCaller(s.Logger.Error().Err("error", err)).Msg("error in parsing event")
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// webhookVerifyToken extracts the verify token from a decoded webhook payload.
+//
+// A decoder is used as decrypted payloads are followed by padding.
+func webhookVerifyToken(payload []byte) string {
+	e := &struct {
+		Data struct {
+			VerifyToken string `json:"verify_token"`
+		} `json:"d"`
+	}{}
+	if err := json.NewDecoder(bytes.NewReader(payload)).Decode(e); err != nil {
+		return ""
 	}
+	return e.Data.VerifyToken
 }
@@ -3,11 +3,13 @@ package kook
 import (
 	"bytes"
 	"compress/zlib"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
+	"math/rand"
+	"net/url"
 	"strconv"
 	"sync/atomic"
 	"time"
@@ -18,19 +20,69 @@ import (
 // ErrWSAlreadyOpen is the error when connecting with connected websocket.
 var ErrWSAlreadyOpen = errors.New("websocket is already opened")
 
+// ErrHeartbeatTimeout is the error when the gateway does not acknowledge the heartbeat in time.
+var ErrHeartbeatTimeout = errors.New("heartbeat ack timeout")
+
+// ErrGatewayReconnect is the error when the gateway asks to reconnect with a new session.
+var ErrGatewayReconnect = errors.New("gateway requested reconnect")
+
 // Open starts a websocket connection. It does not block the function.
+//
+// A *GatewayError is returned if the gateway refuses the connection, which could be tested against
+// the EventStatusCode consts with errors.Is, e.g. errors.Is(err, EventStatusInvalidToken).
+//
+// If the session has been connected before, it tries to resume the previous gateway session.
+// Once connected, the session reconnects on failures following its ReconnectPolicy.
 func (s *Session) Open() (err error) {
 	//s.log(LogInfo, "called")
 	addCaller(s.Logger.Info()).Msg("called")
 
+	s.Lock()
+	s.closed = false
+	s.Unlock()
+	return s.connect()
+}
+
+// connect opens the connection and emits lifecycle events, it is shared by Open and reconnect.
+func (s *Session) connect() (err error) {
+	resumeFailed, err := s.open()
+	if err != nil {
+		if resumeFailed {
+			s.emitLifecycle(LifecycleResumeFailed, 0, err)
+		}
+		return
+	}
+	s.ReconnectPolicy.Reset()
+	s.emitLifecycle(LifecycleConnected, 0, nil)
+	return
+}
+
+func (s *Session) open() (resumeFailed bool, err error) {
 	s.Lock()
 	defer s.Unlock()
 
+	if s.isShutdown() {
+		return false, ErrSessionShutdown
+	}
+
+	if err = s.context().Err(); err != nil {
+		return
+	}
+
 	if s.wsConn != nil {
 		addCaller(s.Logger.Error()).Msg("websocket is already open")
-		return ErrWSAlreadyOpen
+		return false, ErrWSAlreadyOpen
 	}
 
+	if s.state != SessionStateReconnecting {
+		s.state = SessionStateConnecting
+	}
+	defer func() {
+		if err != nil && s.state == SessionStateConnecting {
+			s.state = SessionStateDisconnected
+		}
+	}()
+
 	if s.gateway == "" {
 		s.gateway, err = s.Gateway()
 		if err != nil {
@@ -39,9 +91,27 @@ func (s *Session) Open() (err error) {
 		}
 	}
 
+	gateway := s.gateway
+	sequence := atomic.LoadInt64(s.sequence)
+	resuming := s.sessionID != "" && sequence > 0
+	if resuming {
+		u, _ := url.Parse(gateway)
+		q := u.Query()
+		q.Set("resume", "1")
+		q.Set("sn", strconv.FormatInt(sequence, 10))
+		q.Set("session_id", s.sessionID)
+		u.RawQuery = q.Encode()
+		gateway = u.String()
+		addCaller(s.Logger.Info()).Int64("seq", sequence).Str("session_id", s.sessionID).Msg("resuming gateway session")
+	}
+
 	//s.log(LogInfo, "connecting to gateway %s", s.gateway)
 	addCaller(s.Logger.Info()).Str("gateway_url", s.gateway).Msg("connecting to gateway")
-	s.wsConn, _, err = websocket.DefaultDialer.Dial(s.gateway, http.Header{})
+	dialer := s.Dialer
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+	s.wsConn, _, err = dialer.Dial(gateway, s.Header)
 	if err != nil {
 		addCaller(s.Logger.Error()).
 			Str("gateway_url", s.gateway).
@@ -55,6 +125,9 @@ func (s *Session) Open() (err error) {
 	s.wsConn.SetCloseHandler(func(code int, text string) error {
 		return nil
 	})
+	if s.ReadLimit > 0 {
+		s.wsConn.SetReadLimit(s.ReadLimit)
+	}
 
 	defer func() {
 		if err != nil {
@@ -74,8 +147,8 @@ func (s *Session) Open() (err error) {
 	}
 	if e.Signal != EventSignalHello {
 		s.gateway = ""
-		err = fmt.Errorf("expecting signal hello, got singal %d", e.Signal)
-		return err
+		err = fmt.Errorf("%w: expecting signal hello, got signal %d", ErrUnexpectedSignal, e.Signal)
+		return false, err
 	}
 	//s.log(LogInfo, "signal hello received")
 	addCaller(s.Logger.Info()).Msg("signal hello received")
@@ -84,19 +157,31 @@ func (s *Session) Open() (err error) {
 	var h EventDataHello
 	if err = json.Unmarshal(e.Data, &h); err != nil {
 		addCaller(s.Logger.Error()).Err("err", err).Msg("error unmarshalling hello")
-		err = fmt.Errorf("error unmarshalling hello, %s", err.Error())
+		err = fmt.Errorf("error unmarshalling hello, %w", err)
 		return
 	}
 	if h.Code != EventStatusOk {
 		s.gateway = ""
 		addCaller(s.Logger.Error()).Int("code", int(h.Code)).Msg("error status is not ok")
-		err = fmt.Errorf("expecting status ok, received %d", h.Code)
+		err = &GatewayError{Code: h.Code, Resuming: resuming}
+		switch h.Code {
+		case EventStatusResumeFailed, EventStatusSessionExpired, EventStatusInvalidSequenceNumber:
+			s.clearGatewaySession()
+			resumeFailed = resuming
+		}
 		return
 	}
+	s.sessionID = h.SessionID
+	s.state = SessionStateConnected
+	s.reconnectAttempt = 0
 
 	s.listening = make(chan interface{})
-	go s.heartbeat(s.wsConn, s.listening)
+	s.pong = make(chan struct{}, 1)
+	go s.heartbeat(s.wsConn, s.listening, s.pong)
 	go s.listen(s.wsConn, s.listening)
+	if s.ctx != nil {
+		go s.watchContext(s.ctx, s.listening)
+	}
 
 	addCaller(s.Logger.Info()).Msg("exiting")
 
@@ -105,6 +190,25 @@ func (s *Session) Open() (err error) {
 	return
 }
 
+// watchContext closes the session when ctx is done, until the connection is closed.
+func (s *Session) watchContext(ctx context.Context, listening <-chan interface{}) {
+	select {
+	case <-ctx.Done():
+		addCaller(s.Logger.Info()).Err("err", ctx.Err()).Msg("context is done, closing")
+		s.Close()
+	case <-listening:
+	}
+}
+
+// clearGatewaySession drops the gateway session so that the next Open starts a new one.
+// The session must be locked.
+func (s *Session) clearGatewaySession() {
+	s.gateway = ""
+	s.sessionID = ""
+	atomic.StoreInt64(s.sequence, 0)
+	s.snStore.Clear()
+}
+
 func (s *Session) onEvent(messageType int, message []byte) (e *Event, err error) {
 	var reader io.Reader
 	reader = bytes.NewBuffer(message)
@@ -144,6 +248,13 @@ func (s *Session) onEvent(messageType int, message []byte) (e *Event, err error)
 	if e.Signal == EventSignalPong {
 		s.Lock()
 		s.LastHeartbeatAck = time.Now().UTC()
+		if !s.LastHeartbeatSent.IsZero() {
+			s.latency.add(s.LastHeartbeatAck.Sub(s.LastHeartbeatSent))
+		}
+		select {
+		case s.pong <- struct{}{}:
+		default:
+		}
 		s.Unlock()
 		addCaller(s.Logger.Debug()).Msg("got heartbeat ACK")
 		//s.log(LogDebug, "got heartbeat ACK")
@@ -153,11 +264,9 @@ func (s *Session) onEvent(messageType int, message []byte) (e *Event, err error)
 	if e.Signal == EventSignalReconnect {
 		addCaller(s.Logger.Info()).Msg("closing current ws and reconnecting in response to Reconnect signal")
 		//s.log(LogInfo, "closing current ws and reconnecting in response to Reconnect signal")
-		s.CloseWithCode(websocket.CloseServiceRestart)
+		s.closeWithCode(websocket.CloseServiceRestart, ErrGatewayReconnect)
 		s.Lock()
-		s.gateway = ""
-		atomic.StoreInt64(s.sequence, 0)
-		s.snStore.Clear()
+		s.clearGatewaySession()
 		s.Unlock()
 		s.reconnect()
 		return
@@ -166,6 +275,7 @@ func (s *Session) onEvent(messageType int, message []byte) (e *Event, err error)
 	if e.Signal == EventSignalResumeAck {
 		addCaller(s.Logger.Info()).Msg("all missing message are sent, received Resume Ack signal")
 		//s.log(LogInfo, "all missing message are sent, received Resume Ack signal")
+		s.emitLifecycle(LifecycleResumed, 0, nil)
 		return
 	}
 
@@ -241,6 +351,11 @@ func (s *Session) listen(wsConn *websocket.Conn, listening <-chan interface{}) {
 	for {
 		messageType, message, err := wsConn.ReadMessage()
 		if err != nil {
+			select {
+			case <-listening:
+				return
+			default:
+			}
 			s.RLock()
 			sameConnection := s.wsConn == wsConn
 			s.RUnlock()
@@ -248,7 +363,7 @@ func (s *Session) listen(wsConn *websocket.Conn, listening <-chan interface{}) {
 			if sameConnection {
 				addCaller(s.Logger.Warn()).Str("gateway_url", s.gateway).Err("err", err).Msg("error reading from gateway")
 				//s.log(LogWarning, "error reading from gateway %s websocket, %s", s.gateway, err)
-				err := s.Close()
+				err := s.closeWithCode(websocket.CloseNormalClosure, err)
 				if err != nil {
 					addCaller(s.Logger.Warn()).Err("err", err).Msg("error closing session connection")
 					//s.log(LogWarning, "error closing session connection, %s", err)
@@ -273,62 +388,132 @@ type pingSignal struct {
 	SequenceNumber int64       `json:"sn"`
 }
 
-func (s *Session) heartbeat(wsConn *websocket.Conn, listening <-chan interface{}) {
+func (s *Session) heartbeat(wsConn *websocket.Conn, listening <-chan interface{}, pong <-chan struct{}) {
 	addCaller(s.Logger.Info()).Msg("called")
 	//s.log(LogInfo, "called")
 	if listening == nil || wsConn == nil {
 		return
 	}
-	var err error
-	ticker := time.NewTicker(heartbeatInterval)
-	defer ticker.Stop()
 	for {
-		s.RLock()
-		last := s.LastHeartbeatAck
-		s.RUnlock()
+		err := s.ping(wsConn, listening, pong)
+		if err != nil {
+			select {
+			case <-listening:
+				return
+			default:
+			}
+			s.closeWithCode(websocket.CloseNormalClosure, err)
+			s.reconnect()
+			return
+		}
+		wait := s.heartbeatInterval
+		if s.heartbeatJitter > 0 {
+			wait += time.Duration(rand.Int63n(int64(2*s.heartbeatJitter)+1)) - s.heartbeatJitter
+		}
+		select {
+		case <-time.After(wait):
+		case <-listening:
+			return
+		}
+	}
+}
+
+// ping sends a heartbeat and waits for the ack, retrying twice after 2 and 4 seconds before giving up.
+func (s *Session) ping(wsConn *websocket.Conn, listening <-chan interface{}, pong <-chan struct{}) (err error) {
+	for i := 0; ; i++ {
+		select {
+		case <-pong:
+		default:
+		}
 		sequence := atomic.LoadInt64(s.sequence)
 		//s.log(LogDebug, "sending gateway websocket heartbeat seq %d", sequence)
 		addCaller(s.Logger.Debug()).Int64("seq", sequence).Msg("sending gateway websocket heartbeat")
 
-		s.wsMutex.Lock()
+		s.Lock()
 		s.LastHeartbeatSent = time.Now().UTC()
+		timeout := s.latency.timeout(s.heartbeatTimeout, s.heartbeatInterval)
+		s.Unlock()
+		s.wsMutex.Lock()
 		err = wsConn.WriteJSON(pingSignal{
 			Signal:         EventSignalPing,
 			SequenceNumber: sequence,
 		})
 		s.wsMutex.Unlock()
-		if err != nil || time.Now().UTC().Sub(last) > 36*time.Second {
-			if err != nil {
-				addCaller(s.Logger.Error()).Str("gateway_url", s.gateway).Err("err", err).Msg("error sending heartbeat to gateway")
-				//s.log(LogError, "error sending heartbeat to gateway %s, %s", s.gateway, err)
-			} else {
-				addCaller(s.Logger.Error()).Dur("latency", time.Now().UTC().Sub(last)).Msg("ACK not received, reconnect")
-				//s.log(LogError, "haven't gotten a heartbeat ACK in %v, triggering a reconnection", time.Now().UTC().Sub(last))
-			}
-			s.Close()
-			s.reconnect()
+		if err != nil {
+			addCaller(s.Logger.Error()).Str("gateway_url", s.gateway).Err("err", err).Msg("error sending heartbeat to gateway")
+			//s.log(LogError, "error sending heartbeat to gateway %s, %s", s.gateway, err)
+			return err
 		}
 		select {
-		case <-ticker.C:
+		case <-pong:
+			return nil
+		case <-time.After(timeout):
 		case <-listening:
-			return
+			return nil
+		}
+		if i == len(heartbeatRetries) {
+			addCaller(s.Logger.Error()).Dur("timeout", timeout).Msg("ACK not received, reconnect")
+			//s.log(LogError, "haven't gotten a heartbeat ACK in %v, triggering a reconnection", time.Now().UTC().Sub(last))
+			return ErrHeartbeatTimeout
+		}
+		addCaller(s.Logger.Warn()).Dur("timeout", timeout).Int("retry", i+1).Msg("ACK not received, retrying")
+		select {
+		case <-time.After(heartbeatRetries[i]):
+		case <-listening:
+			return nil
 		}
-
 	}
 }
 
+// reconnect reconnects to the gateway until connected, the policy gives up, or the session is closed.
+// Only one reconnect loop runs at a time.
 func (s *Session) reconnect() {
 	addCaller(s.Logger.Info()).Msg("called")
 	//s.log(LogInfo, "called")
+	if !atomic.CompareAndSwapInt32(&s.reconnecting, 0, 1) {
+		addCaller(s.Logger.Info()).Msg("already reconnecting")
+		return
+	}
+	defer atomic.StoreInt32(&s.reconnecting, 0)
+
+	s.Lock()
+	if s.closed {
+		s.Unlock()
+		return
+	}
+	stop := make(chan struct{})
+	s.reconnectStop = stop
+	s.Unlock()
+	ctx := s.context()
+
 	var err error
-	wait := time.Duration(1)
-	for {
+	for attempt := 1; ; attempt++ {
+		s.Lock()
+		if s.closed {
+			s.Unlock()
+			return
+		}
+		s.state = SessionStateReconnecting
+		s.reconnectAttempt = attempt
+		s.Unlock()
+		s.emitLifecycle(LifecycleReconnecting, attempt, err)
 		//s.log(LogInfo, "trying to reconnect to gateway")
 		addCaller(s.Logger.Info()).Msg("trying to reconnect to gateway")
-		err = s.Open()
+		err = s.connect()
+		if err == ErrSessionShutdown {
+			addCaller(s.Logger.Info()).Msg("session is shut down, stop reconnecting")
+			return
+		}
 		if err == nil {
 			addCaller(s.Logger.Info()).Msg("successfully reconnected to gateway")
 			//s.log(LogInfo, "successfully reconnected to gateway")
+			s.RLock()
+			closed := s.closed
+			s.RUnlock()
+			if closed {
+				// Closed by the user while connecting.
+				s.closeWithCode(websocket.CloseNormalClosure, nil)
+			}
 			return
 		}
 
@@ -340,23 +525,68 @@ func (s *Session) reconnect() {
 
 		addCaller(s.Logger.Error()).Err("err", err).Msg("error reconnecting to gateway")
 		//s.log(LogError, "error reconnecting to gateway, %s", err)
-		<-time.After(wait * time.Second)
-		wait *= 2
-		if wait > 600 {
-			wait = 600
+		if isFatalGatewayError(err) {
+			s.giveUp(err)
+			return
+		}
+		wait, ok := s.ReconnectPolicy.Next(attempt, err)
+		if !ok {
+			s.giveUp(err)
+			return
+		}
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-stop:
+			t.Stop()
+			addCaller(s.Logger.Info()).Msg("session is closed, stop reconnecting")
+			return
+		case <-ctx.Done():
+			t.Stop()
+			addCaller(s.Logger.Info()).Err("err", ctx.Err()).Msg("context is done, stop reconnecting")
+			return
 		}
 	}
 }
 
+// giveUp stops reconnecting after err.
+func (s *Session) giveUp(err error) {
+	addCaller(s.Logger.Error()).Err("err", err).Msg("giving up reconnecting")
+	s.Lock()
+	s.state = SessionStateDisconnected
+	s.reconnectAttempt = 0
+	s.Unlock()
+	s.emitLifecycle(LifecycleGaveUp, 0, err)
+}
+
 // Close closes a websocket connection.
+//
+// Event handlers and http api requests are not waited, use Shutdown for a graceful stop.
 func (s *Session) Close() (err error) {
 	return s.CloseWithCode(websocket.CloseNormalClosure)
 }
 
 // CloseWithCode closes a websocket connection with custom websocket closing code.
+//
+// A running reconnect loop is stopped.
 func (s *Session) CloseWithCode(code int) (err error) {
+	s.Lock()
+	s.closed = true
+	s.Unlock()
+	s.stopReconnect()
+	return s.closeWithCode(code, nil)
+}
+
+// closeWithCode closes the websocket connection, the cause is passed to LifecycleDisconnected handlers.
+func (s *Session) closeWithCode(code int, cause error) (err error) {
 	addCaller(s.Logger.Info()).Msg("called")
 	//s.log(LogInfo, "called")
+	var disconnected bool
+	defer func() {
+		if disconnected {
+			s.emitLifecycle(LifecycleDisconnected, 0, cause)
+		}
+	}()
 	s.Lock()
 	if s.listening != nil {
 		//s.log(LogInfo, "closing listening channel")
@@ -384,6 +614,8 @@ func (s *Session) CloseWithCode(code int) (err error) {
 			addCaller(s.Logger.Info()).Err("err", err).Msg("error closing websocket")
 		}
 		s.wsConn = nil
+		s.state = SessionStateDisconnected
+		disconnected = true
 	}
 	s.Unlock()
 	return